@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"mruiz/cliWeather/internal/render"
+
+	"github.com/spf13/cobra"
+)
+
+var flagChart string
+
+// addChartFlag registra --chart; sin valor equivale a --chart spark
+func addChartFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&flagChart, "chart", "", "Chart temperature, rain chance and wind instead of listing hours: spark or line")
+	cmd.Flags().Lookup("chart").NoOptDefVal = string(render.SparkChart)
+	_ = cmd.RegisterFlagCompletionFunc("chart", cobra.FixedCompletions([]string{string(render.SparkChart), string(render.LineChart)}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// chartKind valida --chart en el idioma de la interfaz
+func chartKind() (render.ChartKind, error) {
+	k, err := render.ParseChartKind(flagChart)
+	if err != nil {
+		return "", errors.New(uiLocale().Sprintf("unsupported --chart %q (expected spark or line)", flagChart))
+	}
+	return k, nil
+}
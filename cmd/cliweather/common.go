@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"mruiz/cliWeather/internal/cache"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/icons"
+	"mruiz/cliWeather/internal/provider"
+	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Flags compartidos por los comandos que consultan a un proveedor
+var (
+	flagCity     string
+	flagCities   []string
+	flagLang     string
+	flagAPIKey   string
+	flagProvider string
+	flagJSON     bool
+	flagNoCache  bool
+	flagRefresh  bool
+)
+
+// addQueryFlags registra en cmd los flags de consulta comunes
+func addQueryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&flagCity, "city", "c", "", "City name or lat,lon query (default: location in the config file, or Vigo)")
+	_ = cmd.RegisterFlagCompletionFunc("city", completeCity)
+	addProviderFlags(cmd)
+}
+
+// addCitiesFlag registra un --city repetible, para los comandos que admiten
+// varias localizaciones. El primer valor hace las veces de --city.
+func addCitiesFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&flagCities, "city", "c", nil, "City name or lat,lon query; repeat it for several (default: location in the config file, or Vigo)")
+	_ = cmd.RegisterFlagCompletionFunc("city", completeCity)
+}
+
+// addProviderFlags registra los flags que eligen y configuran el proveedor
+func addProviderFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&flagLang, "lang", "l", "", "Language for labels and conditions: es, en, fr, gl or pt (or WEATHER_LANG)")
+	cmd.Flags().StringVar(&flagAPIKey, "apikey", "", "WeatherAPI key (or set WEATHER_API_KEY)")
+	cmd.Flags().StringVarP(&flagProvider, "provider", "p", "", "Weather provider or ordered fallback list, e.g. weatherapi,openmeteo (or set WEATHER_PROVIDER)")
+	cmd.Flags().BoolVar(&flagNoCache, "no-cache", false, "Do not read or write the response cache")
+	cmd.Flags().BoolVar(&flagRefresh, "refresh", false, "Ignore cached responses but store the fresh one")
+
+	_ = cmd.RegisterFlagCompletionFunc("provider", completeProviders)
+}
+
+// loadConfig lee la configuración con los flags de cmd y rellena con ella
+// los flags comunes, que a partir de aquí tienen el valor efectivo (--city ya
+// con el alias de una localización guardada traducido)
+func loadConfig(cmd *cobra.Command) (config.Config, error) {
+	cfg, err := config.Load(flagConfig, cmd.Flags())
+	flagLang = cfg.Language
+	if err != nil {
+		return cfg, err
+	}
+	flagCity = cfg.Resolve(cfg.Location)
+	flagAPIKey = cfg.APIKey
+	flagProvider = cfg.Provider
+	if f := cmd.Flags().Lookup("output"); f != nil && !f.Changed && slices.Contains(outputFormats(cmd), cfg.Output) {
+		flagOutput = cfg.Output
+	}
+	return cfg, nil
+}
+
+// uiLocale devuelve el idioma de la interfaz: --lang o el de la
+// configuración, aunque esta tenga errores (para poder explicarlos)
+func uiLocale() *i18n.Locale {
+	lang := flagLang
+	if lang == "" {
+		cfg, _ := config.Load(flagConfig, nil)
+		lang = cfg.Language
+	}
+	return i18n.New(lang)
+}
+
+// renderOptions decide color y emojis según theme.color (auto mira NO_COLOR y
+// si la salida es una terminal) y theme.emoji, y las unidades, los iconos y
+// el idioma según la configuración
+func renderOptions(cfg config.Config) (render.Options, error) {
+	useColor := cfg.Color == "always" || (cfg.Color == "auto" && !envNoColor() && isTerminal(os.Stdout))
+	useEmoji := cfg.Emoji
+
+	u, err := unitSystem(cfg)
+	if err != nil {
+		return render.Options{}, err
+	}
+	set, err := iconSet(cfg)
+	if err != nil {
+		return render.Options{}, err
+	}
+	return render.Options{Color: useColor, Emoji: useEmoji, Units: u, Locale: uiLocale(), Icons: set, Width: terminalWidth()}, nil
+}
+
+// terminalWidth devuelve el ancho de la terminal de salida, o 0 si no lo es
+func terminalWidth() int {
+	w, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return w
+}
+
+// iconSet elige el juego de iconos de theme.icons y le aplica theme.icons_file
+func iconSet(cfg config.Config) (*icons.Set, error) {
+	set, err := icons.Get(cfg.Icons)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.IconsFile == "" {
+		return set, nil
+	}
+	return icons.Load(cfg.IconsFile, set)
+}
+
+func unitSystem(cfg config.Config) (units.System, error) {
+	u, err := units.Parse(cfg.Units)
+	if err != nil {
+		return u, err
+	}
+	if cfg.WindUnit != "" {
+		if u.WindUnit, err = units.ParseWind(cfg.WindUnit); err != nil {
+			return u, err
+		}
+	}
+	return u, nil
+}
+
+// completeProviders completa el último elemento de una lista separada por comas
+func completeProviders(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+	var out []string
+	for _, name := range provider.Names() {
+		out = append(out, prefix+name)
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// newProvider construye el proveedor elegido con --provider / WEATHER_PROVIDER
+func newProvider(cfg config.Config) (weather.Provider, error) {
+	return buildProvider(cfg, flagRefresh)
+}
+
+// skipWarned son los proveedores de la lista de los que ya se ha avisado
+// que se saltan, para no repetir el aviso si se construye más de una vez
+var skipWarned = map[string]bool{}
+
+// buildProvider es newProvider con el modo refresh explícito: con refresh
+// se ignora lo que haya en caché pero se guarda la respuesta nueva
+func buildProvider(cfg config.Config, refresh bool) (weather.Provider, error) {
+	opt := providerOptions(cfg)
+	if cfg.EnableCache {
+		opt.Cache = newCache(cfg, refresh)
+	}
+	l := uiLocale()
+	opt.OnSkip = func(name string, err error) {
+		if !skipWarned[name] {
+			skipWarned[name] = true
+			fmt.Fprintln(os.Stderr, l.Sprintf("warning: skipping %s (%v)", name, err))
+		}
+	}
+	p, err := provider.NewList(flagProvider, opt)
+	if err != nil {
+		return nil, err
+	}
+	if chain, ok := p.(*provider.Chain); ok {
+		chain.OnFailover = func(failed weather.Provider, err error) {
+			fmt.Fprintln(os.Stderr, l.Sprintf("warning: %s failed (%v), trying the next provider", failed.Name(), err))
+		}
+	}
+	return p, nil
+}
+
+// providerOptions devuelve las opciones de los proveedores sin caché. El
+// idioma va normalizado como el de la interfaz (es_ES.UTF-8 → es), que es el
+// formato que entienden las APIs.
+func providerOptions(cfg config.Config) provider.Options {
+	return provider.Options{
+		APIKey:  flagAPIKey,
+		Lang:    i18n.New(cfg.Language).Lang(),
+		Timeout: cfg.Timeout,
+	}
+}
+
+// totalTimeout da a una cadena de proveedores un intento completo por eslabón
+func totalTimeout(p weather.Provider, cfg config.Config) time.Duration {
+	if chain, ok := p.(*provider.Chain); ok {
+		return time.Duration(chain.Len()) * cfg.Timeout
+	}
+	return cfg.Timeout
+}
+
+// newCache devuelve la caché en disco según la configuración, o nil si no
+// hay directorio de caché disponible (en ese caso se trabaja sin caché).
+func newCache(cfg config.Config, refresh bool) *cache.Cache {
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return nil
+	}
+	ttl := cfg.CacheTTL
+	if refresh {
+		ttl = 0
+	}
+	return cache.New(dir, ttl)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"mruiz/cliWeather/internal/config"
+	"testing"
+)
+
+func TestProviderOptions_Lang(t *testing.T) {
+	tests := []struct{ language, want string }{
+		{"es", "es"},
+		{"es_ES.UTF-8", "es"},
+		{"pt-BR", "pt"},
+		{"gl_ES", "gl"},
+		{"C.UTF-8", "en"},
+		{"", "es"},
+	}
+	for _, tt := range tests {
+		if got := providerOptions(config.Config{Language: tt.language}).Lang; got != tt.want {
+			t.Errorf("providerOptions(%q).Lang = %q, want %q", tt.language, got, tt.want)
+		}
+	}
+}
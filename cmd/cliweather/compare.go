@@ -0,0 +1,73 @@
+package main
+
+import (
+	"mruiz/cliWeather/internal/render"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <location> <location>...",
+	Short: "Compare the forecast of several locations side by side",
+	Long: `Show the forecast of several locations side by side: one column per
+location and, for each day, rows with the maximum and minimum temperature,
+the chance of rain, the maximum wind and the UV index. The best value of each
+row is highlighted: the maximum closest to 22 °C, the highest minimum and the
+lowest rain chance, wind and UV. Columns that do not fit in the terminal
+continue in another table. Saved location names are accepted.`,
+	Example: `  cliweather compare Vigo Madrid Lisbon --days 3
+  cliweather compare casa oficina -o csv`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeCity,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		flagDays = cfg.Days
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		opt, err := renderOptions(cfg)
+		if err != nil {
+			return err
+		}
+		p, err := newProvider(cfg)
+		if err != nil {
+			return err
+		}
+		queries := make([]string, len(args))
+		for i, arg := range args {
+			queries[i] = cfg.Resolve(arg)
+		}
+
+		fetched, fetchErr := fetchMany(p, cfg, queries, opt.Locale)
+		if len(fetched) == 0 {
+			return fetchErr
+		}
+		c := render.NewComparison(fetched, flagDays)
+		switch format {
+		case "json":
+			err = render.RenderJSONComparison(render.NewJSONComparison(c, opt), os.Stdout)
+		case "csv", "tsv":
+			err = writeTable(format, render.NewComparisonTable(c, opt.Units), os.Stdout)
+		default:
+			render.RenderComparison(c, os.Stdout, opt)
+		}
+		if err != nil {
+			return err
+		}
+		return fetchErr
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	addProviderFlags(compareCmd)
+	addOutputFlag(compareCmd, "text", "json", "csv", "tsv")
+	compareCmd.Flags().IntVarP(&flagDays, "days", "d", 1, "Forecast days (1-3 on free tier)")
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -9,29 +10,26 @@ import (
 
 var completionCmd = &cobra.Command{
 	Use:   "completion [bash|zsh|fish|powershell]",
-	Short: "Genera el script de autocompletado para tu shell",
-	Long: `Genera autocompletado para cliweather.
-
-Bash:
+	Short: "Generate the autocompletion script for your shell",
+	Long:  "Generate shell autocompletion for cliweather.",
+	// Los ejemplos son comandos de shell y no se traducen
+	Example: `  # Bash
   source <(cliweather completion bash)
-  # persistente:
-  cliweather completion bash > /etc/bash_completion.d/cliweather  (root)
-  # o: cliweather completion bash > ~/.local/share/bash-completion/cliweather
+  cliweather completion bash > /etc/bash_completion.d/cliweather  # (root)
+  cliweather completion bash > ~/.local/share/bash-completion/cliweather
 
-Zsh:
+  # Zsh
   echo 'autoload -U compinit; compinit' >> ~/.zshrc
-  cliweather completion zsh > "${fpath[1]}/_cliweather"   # requiere $fpath writable
-  # o: mkdir -p ~/.zsh/completions && cliweather completion zsh > ~/.zsh/completions/_cliweather
-  #    y añade a ~/.zshrc: fpath=(~/.zsh/completions $fpath)
+  cliweather completion zsh > "${fpath[1]}/_cliweather"
+  mkdir -p ~/.zsh/completions && cliweather completion zsh > ~/.zsh/completions/_cliweather
+  echo 'fpath=(~/.zsh/completions $fpath)' >> ~/.zshrc
 
-Fish:
+  # Fish
   cliweather completion fish > ~/.config/fish/completions/cliweather.fish
 
-PowerShell:
+  # PowerShell
   cliweather completion powershell | Out-String | Invoke-Expression
-  # persistente:
-  cliweather completion powershell > $PROFILE
-`,
+  cliweather completion powershell > $PROFILE`,
 	Args: cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 	ValidArgs: []string{
 		"bash", "zsh", "fish", "powershell",
@@ -50,7 +48,7 @@ PowerShell:
 		case "powershell":
 			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
 		default:
-			return fmt.Errorf("unsupported shell: %s", shell)
+			return errors.New(uiLocale().Sprintf("unsupported shell: %s", shell))
 		}
 	},
 }
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/render"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var flagInitForce bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and edit the configuration",
+	Long: `Show the settings in effect and where each one comes from, and edit the
+config file. Flags win over environment variables (CLIWEATHER_*), which win
+over the config file, which wins over the defaults.`,
+	Example: `  cliweather config show
+  cliweather config set units imperial
+  cliweather config unset units
+  cliweather config init`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show every setting with its value and source",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values, file, loadErr := config.Inspect(flagConfig, cmd.Flags())
+		for i, v := range values {
+			if v.Key == "api_key" {
+				values[i].Value = maskSecret(v.Value)
+			}
+		}
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			doc := struct {
+				File     string         `json:"file"`
+				Settings []config.Value `json:"settings"`
+			}{file, values}
+			if err := enc.Encode(doc); err != nil {
+				return err
+			}
+			return loadErr
+		}
+
+		cfg, _ := config.Load(flagConfig, cmd.Flags())
+		opt, err := renderOptions(cfg)
+		if err != nil {
+			return err
+		}
+		if file == "" {
+			path, _ := config.Path(flagConfig)
+			fmt.Println(opt.Locale.Sprintf("Config file: none (%s would be used)", path))
+		} else {
+			fmt.Println(opt.Locale.Sprintf("Config file: %s", file))
+		}
+		fmt.Println()
+		render.RenderSettings(values, os.Stdout, opt)
+		return loadErr
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:               "get <key>",
+	Short:             "Print the value in effect for a setting",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSettings(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := settingKey(args[0])
+		if err != nil {
+			return err
+		}
+		values, _, err := config.Inspect(flagConfig, cmd.Flags())
+		for _, v := range values {
+			if v.Key == k.Name {
+				fmt.Println(v.Value)
+			}
+		}
+		return err
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:               "set <key> <value>",
+	Short:             "Save a setting in the config file",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeSettings(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := settingKey(args[0])
+		if err != nil {
+			return err
+		}
+		path, err := config.Path(flagConfig)
+		if err != nil {
+			return err
+		}
+		if err := config.Set(path, k.Name, args[1]); err != nil {
+			return err
+		}
+		warnOverridden(cmd, k)
+		return nil
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:               "unset <key>",
+	Short:             "Remove a setting from the config file",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSettings(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		k, err := settingKey(args[0])
+		if err != nil {
+			return err
+		}
+		path, err := config.Path(flagConfig)
+		if err != nil {
+			return err
+		}
+		return config.Unset(path, k.Name)
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the path of the config file",
+	Long: `Print the config file that is read: --config, CLIWEATHER_CONFIG or the
+first of config.yaml, config.yml and config.toml in the user config
+directory. If there is none, print where "config set" and "config init"
+would create it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.Path(flagConfig)
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented config file with the defaults",
+	Long: `Write a config file with every setting at its default value and a comment
+explaining it. The file is TOML when its name ends in .toml (see --config)
+and YAML otherwise.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		l := uiLocale()
+		path, err := config.Path(flagConfig)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err == nil && !flagInitForce {
+			return errors.New(l.Sprintf("%s already exists (use --force to overwrite it)", path))
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, config.Template(path), 0o600); err != nil {
+			return err
+		}
+		fmt.Println(l.Sprintf("Wrote %s", path))
+		return nil
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file and the CLIWEATHER_* variables",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(flagConfig, cmd.Flags())
+		if err != nil {
+			return err
+		}
+		l := uiLocale()
+		if cfg.File == "" {
+			fmt.Println(l.Sprintf("No config file; the settings are valid"))
+			return nil
+		}
+		fmt.Println(l.Sprintf("%s is valid", cfg.File))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd, configGetCmd, configSetCmd, configUnsetCmd, configPathCmd, configInitCmd, configValidateCmd)
+
+	addOutputFlag(configShowCmd, "text", "json")
+	configInitCmd.Flags().BoolVarP(&flagInitForce, "force", "f", false, "Overwrite an existing config file")
+}
+
+// settingKey busca el ajuste con ese nombre o explica cuáles hay
+func settingKey(name string) (config.Key, error) {
+	k, ok := config.Lookup(name)
+	if !ok {
+		return k, errors.New(uiLocale().Sprintf("unknown setting %q (expected one of %s)", name, strings.Join(config.Names(), ", ")))
+	}
+	return k, nil
+}
+
+// warnOverridden avisa si el valor recién guardado no es el efectivo porque
+// lo tapa una variable de entorno
+func warnOverridden(cmd *cobra.Command, k config.Key) {
+	values, _, _ := config.Inspect(flagConfig, cmd.Flags())
+	for _, v := range values {
+		if v.Key == k.Name && v.Source == config.FromEnv {
+			fmt.Fprintln(os.Stderr, uiLocale().Sprintf("warning: %s overrides the value in the config file", v.Origin))
+		}
+	}
+}
+
+// completeSettings completa nombres de ajuste en los primeros n argumentos
+func completeSettings(n int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return config.Names(), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// maskSecret deja ver solo los últimos cuatro caracteres de una clave
+func maskSecret(s string) string {
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/weather"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+)
+
+var currentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show the current weather",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		tmpl, err := loadTemplate(format)
+		if err != nil {
+			return err
+		}
+		opt, err := renderOptions(cfg)
+		if err != nil {
+			return err
+		}
+		p, err := newProvider(cfg)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), totalTimeout(p, cfg))
+		defer cancel()
+
+		// Las barras de estado necesitan las horas para el tooltip de Waybar;
+		// se pide siempre lo mismo para que el sondeo frecuente use la caché
+		req := weather.Request{Query: flagCity, AQI: flagAQI}
+		var w *weather.Forecast
+		if slices.Contains(barFormats, format) {
+			req.Days = 1
+			w, err = p.Forecast(ctx, req)
+		} else {
+			w, err = weather.FetchCurrent(ctx, p, req)
+		}
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			return render.RenderJSON(render.NewJSONDocument(w, opt), os.Stdout)
+		}
+		if slices.Contains(barFormats, format) {
+			return renderBar(format, w, opt, os.Stdout)
+		}
+		if tmpl != nil {
+			return render.RenderTemplate(tmpl, w, os.Stdout, opt)
+		}
+		render.RenderCurrent(w, os.Stdout, opt)
+		render.RenderAirQuality(w.Current.AirQuality, os.Stdout, opt)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(currentCmd)
+
+	addQueryFlags(currentCmd)
+	addOutputFlag(currentCmd, "text", "json", "statusline", "waybar", "i3blocks", "polybar")
+	addTemplateFlags(currentCmd)
+	currentCmd.Flags().BoolVar(&flagAQI, "aqi", false, "Include air quality (weatherapi)")
+}
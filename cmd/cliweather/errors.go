@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mruiz/cliWeather/internal/api/weatherapi"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+)
+
+// Códigos de salida del proceso, pensados para que los scripts puedan
+// reaccionar a cada tipo de fallo sin parsear mensajes. El éxito es el 0
+// habitual de terminar main sin llamar a os.Exit.
+const (
+	exitGeneric          = 1
+	exitMissingKey       = 2
+	exitInvalidKey       = 3
+	exitKeyDisabled      = 4
+	exitQuotaExceeded    = 5
+	exitLocationNotFound = 6
+	exitAccessDenied     = 7
+	exitBadRequest       = 8
+	exitUnavailable      = 9
+	exitBadConfig        = 10
+)
+
+// partialError indica que algunas de las localizaciones pedidas a la vez no
+// se pudieron consultar. Err es el primer fallo y decide el código de salida.
+type partialError struct {
+	Failed, Total int
+	Err           error
+}
+
+func (e *partialError) Error() string {
+	return fmt.Sprintf("%d of %d locations failed: %v", e.Failed, e.Total, e.Err)
+}
+
+func (e *partialError) Unwrap() error { return e.Err }
+
+// explainError traduce un error a un mensaje legible en el idioma de l y a
+// su código de salida.
+func explainError(err error, l *i18n.Locale) (string, int) {
+	var (
+		partial *partialError
+		keyErr  *config.KeyError
+	)
+	switch {
+	case errors.As(err, &partial):
+		_, code := explainError(partial.Err, l)
+		return l.Sprintf("%d of %d locations could not be fetched", partial.Failed, partial.Total), code
+	case errors.As(err, &keyErr):
+		return explainConfig(err, l), exitBadConfig
+	case errors.Is(err, weatherapi.ErrMissingKey):
+		return l.Sprintf("missing API key: export WEATHER_API_KEY=your_api_key or use --apikey"), exitMissingKey
+	case errors.Is(err, weatherapi.ErrInvalidKey):
+		return l.Sprintf("the WeatherAPI key is not valid"), exitInvalidKey
+	case errors.Is(err, weatherapi.ErrKeyDisabled):
+		return l.Sprintf("the WeatherAPI key is disabled"), exitKeyDisabled
+	case errors.Is(err, weatherapi.ErrQuotaExceeded):
+		return l.Sprintf("the monthly WeatherAPI quota has been exhausted"), exitQuotaExceeded
+	case errors.Is(err, weather.ErrLocationNotFound):
+		return l.Sprintf("no location found for that query"), exitLocationNotFound
+	case errors.Is(err, weatherapi.ErrAccessDenied):
+		return l.Sprintf("your WeatherAPI plan does not give access to this resource"), exitAccessDenied
+	case errors.Is(err, weatherapi.ErrBadRequest):
+		return l.Sprintf("invalid request: %v", err), exitBadRequest
+	case errors.Is(err, weatherapi.ErrInternal):
+		return l.Sprintf("WeatherAPI is not available right now, try again later"), exitUnavailable
+	case errors.Is(err, weather.ErrNotSupported):
+		return l.Sprintf("the selected provider does not support this operation (%v)", err), exitGeneric
+	case errors.Is(err, context.DeadlineExceeded):
+		return l.Sprintf("timed out contacting the service"), exitUnavailable
+	default:
+		return err.Error(), exitGeneric
+	}
+}
+
+// explainConfig describe cada ajuste no válido de err, uno por línea
+func explainConfig(err error, l *i18n.Locale) string {
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	lines := make([]string, 0, len(errs))
+	for _, err := range errs {
+		var e *config.KeyError
+		switch {
+		case !errors.As(err, &e):
+			lines = append(lines, err.Error())
+		case errors.Is(e, config.ErrUnknownKey):
+			lines = append(lines, l.Sprintf("unknown setting %q in %s", e.Key, e.Source))
+		default:
+			lines = append(lines, l.Sprintf("invalid %s %q (from %s): %v", e.Key, e.Value, e.Source, e.Err))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
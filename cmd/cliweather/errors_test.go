@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mruiz/cliWeather/internal/api/weatherapi"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/weather"
+	"testing"
+)
+
+func TestExplainError_ExitCodes(t *testing.T) {
+	keyErr := &config.KeyError{Key: "days", Value: "30", Source: "--days", Err: errors.New("must be between 1 and 14")}
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"generic", errors.New("boom"), exitGeneric},
+		{"missing key", weatherapi.ErrMissingKey, exitMissingKey},
+		{"invalid key", weatherapi.ErrInvalidKey, exitInvalidKey},
+		{"key disabled", weatherapi.ErrKeyDisabled, exitKeyDisabled},
+		{"quota", weatherapi.ErrQuotaExceeded, exitQuotaExceeded},
+		{"not found", weather.ErrLocationNotFound, exitLocationNotFound},
+		{"access denied", weatherapi.ErrAccessDenied, exitAccessDenied},
+		{"bad request", weatherapi.ErrBadRequest, exitBadRequest},
+		{"internal", weatherapi.ErrInternal, exitUnavailable},
+		{"not supported", weather.ErrNotSupported, exitGeneric},
+		{"timeout", fmt.Errorf("fetch: %w", context.DeadlineExceeded), exitUnavailable},
+		{"wrapped", fmt.Errorf("Vigo: %w", weatherapi.ErrQuotaExceeded), exitQuotaExceeded},
+		{"config", keyErr, exitBadConfig},
+		{"joined config", errors.Join(keyErr, &config.KeyError{Key: "nope", Source: "config.yaml", Err: config.ErrUnknownKey}), exitBadConfig},
+		{"partial", &partialError{Failed: 1, Total: 3, Err: weather.ErrLocationNotFound}, exitLocationNotFound},
+		{"partial generic", &partialError{Failed: 2, Total: 2, Err: errors.New("boom")}, exitGeneric},
+	}
+	l := i18n.New("en")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, code := explainError(tt.err, l)
+			if code != tt.want {
+				t.Errorf("explainError(%v) code = %d, want %d", tt.err, code, tt.want)
+			}
+			if msg == "" {
+				t.Errorf("explainError(%v) without a message", tt.err)
+			}
+		})
+	}
+
+	if msg, _ := explainError(&partialError{Failed: 1, Total: 3, Err: weather.ErrLocationNotFound}, l); msg != "1 of 3 locations could not be fetched" {
+		t.Errorf("partial message = %q", msg)
+	}
+}
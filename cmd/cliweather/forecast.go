@@ -2,50 +2,102 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"mruiz/cliWeather/internal/api/weatherapi"
-	"mruiz/cliWeather/internal/config"
+	"io"
 	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/weather"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	flagCity     string
 	flagDays     int
-	flagLang     string
-	flagAPIKey   string
 	flagDebug    bool
 	flagDayIndex int
-	flagJSON     bool
+	flagAQI      bool
+	flagAlerts   bool
+	flagWatch    time.Duration
+
+	flagAllFavorites bool
 )
 
 var forecastCmd = &cobra.Command{
 	Use:   "forecast",
-	Short: "Muestra la previsión meteorológica",
+	Short: "Show the weather forecast",
+	Long: `Show the forecast for the default location, for each --city or, with
+--all-favorites, for every saved location. Several locations are fetched in
+parallel and shown in the order they were given; if some of them fail the
+others are still shown.`,
+	Example: `  cliweather forecast -c Vigo -d 3
+  cliweather forecast -c Vigo -c Madrid -c Oslo
+  cliweather forecast --all-favorites -o csv --granularity daily`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg := config.FromEnv()
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		flagDays = cfg.Days
 
-		if flagLang == "" {
-			flagLang = cfg.Language
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
 		}
-		if flagDays == 0 {
-			flagDays = cfg.Days
+		g, err := granularity()
+		if err != nil {
+			return err
 		}
-		if flagAPIKey == "" {
-			flagAPIKey = cfg.APIKey
+		tmpl, err := loadTemplate(format)
+		if err != nil {
+			return err
 		}
-		if flagAPIKey == "" {
-			return fmt.Errorf("missing WEATHER_API_KEY (export WEATHER_API_KEY=tu_api_key o usa --apikey)")
+		opt, err := renderOptions(cfg)
+		if err != nil {
+			return err
 		}
-
-		client := weatherapi.NewClient(flagAPIKey, flagLang, cfg.Timeout)
-		ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+		if opt.Chart, err = chartKind(); err != nil {
+			return err
+		}
+		queries, err := forecastQueries(cfg)
+		if err != nil {
+			return err
+		}
+		if len(queries) > 1 && flagWatch > 0 {
+			return errors.New(opt.Locale.Sprintf("--watch only works with a single location"))
+		}
+		if len(queries) > 1 && slices.Contains(barFormats, format) {
+			return errors.New(opt.Locale.Sprintf("--output %s only works with a single location", format))
+		}
+		if flagWatch > 0 {
+			cfg = watchConfig(cfg, flagWatch)
+		}
+		p, err := newProvider(cfg)
+		if err != nil {
+			return err
+		}
+		if len(queries) > 1 {
+			return forecastMany(p, cfg, queries, format, g, tmpl, opt)
+		}
+		req := weather.Request{Query: queries[0], Days: flagDays, AQI: flagAQI, Alerts: flagAlerts}
+		if flagWatch > 0 {
+			if format != "text" {
+				return errors.New(opt.Locale.Sprintf("--watch only works with --output text"))
+			}
+			draw := renderForecast
+			if tmpl != nil {
+				draw = func(w *weather.Forecast, out io.Writer, opt render.Options) error {
+					return render.RenderTemplate(tmpl, w, out, opt)
+				}
+			}
+			return runWatch(flagWatch, p, req, totalTimeout(p, cfg), draw, opt)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), totalTimeout(p, cfg))
 		defer cancel()
 
-		w, err := client.Forecast(ctx, flagCity, flagDays, false, false)
+		w, err := p.Forecast(ctx, req)
 		if err != nil {
 			return err
 		}
@@ -54,40 +106,60 @@ var forecastCmd = &cobra.Command{
 			fmt.Printf("%+v\n\n", *w)
 		}
 
-		if len(w.Forecast.Forecastday) == 0 {
-			fmt.Fprintln(os.Stdout, "No Forecast available.")
-			return nil
+		// Salida JSON con el esquema versionado (schema/forecast.v1.json)
+		if format == "json" {
+			return render.RenderJSON(render.NewJSONDocument(w, opt), os.Stdout)
 		}
-
-		// Salida JSON cruda si se pide
-		if flagJSON {
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			return enc.Encode(w)
+		if tmpl != nil {
+			return render.RenderTemplate(tmpl, w, os.Stdout, opt)
 		}
-
-		// Determinar opciones de salida
-		useColor := !noColor && !envNoColor() && isTerminal(os.Stdout)
-		useEmoji := !noEmoji // (podrías condicionar por OS o TTY si quisieras)
-		opt := render.Options{Color: useColor, Emoji: useEmoji}
-
-		// Encabezado general y render del/los días
-		render.RenderHeader(w, os.Stdout, opt)
-		if flagDayIndex >= 0 {
-			return render.RenderDay(w, flagDayIndex, len(w.Forecast.Forecastday), os.Stdout, opt)
+		if slices.Contains(barFormats, format) {
+			return renderBar(format, w, opt, os.Stdout)
+		}
+		if slices.Contains(tableFormats, format) {
+			return renderTable(format, g, w, opt, os.Stdout)
 		}
-		return render.RenderAll(w, os.Stdout, opt)
+
+		return renderForecast(w, os.Stdout, opt)
 	},
 }
 
+// renderForecast escribe la previsión en texto: encabezado, avisos, calidad
+// del aire y el día elegido con --day-index o todos
+func renderForecast(w *weather.Forecast, out io.Writer, opt render.Options) error {
+	if len(w.Days) == 0 {
+		fmt.Fprintln(out, opt.Locale.Sprintf("No forecast available."))
+		return nil
+	}
+
+	// Encabezado general, avisos primero y render del/los días
+	render.RenderHeader(w, out, opt)
+	render.RenderAlerts(w, out, opt)
+	render.RenderAirQuality(w.Current.AirQuality, out, opt)
+	if opt.Chart != render.NoChart {
+		render.RenderCharts(w, out, opt)
+	}
+	if flagDayIndex >= 0 {
+		return render.RenderDay(w, flagDayIndex, len(w.Days), out, opt)
+	}
+	return render.RenderAll(w, out, opt)
+}
+
 func init() {
 	rootCmd.AddCommand(forecastCmd)
 
-	forecastCmd.Flags().StringVarP(&flagCity, "city", "c", "Vigo", "City name or query")
+	addCitiesFlag(forecastCmd)
+	addProviderFlags(forecastCmd)
+	forecastCmd.Flags().BoolVar(&flagAllFavorites, "all-favorites", false, "Show every saved location (see cliweather locations)")
+	forecastCmd.MarkFlagsMutuallyExclusive("city", "all-favorites")
+	addOutputFlag(forecastCmd, "text", "json", "csv", "tsv", "ndjson", "statusline", "waybar", "i3blocks", "polybar")
+	addGranularityFlag(forecastCmd)
+	addTemplateFlags(forecastCmd)
+	addChartFlag(forecastCmd)
+	forecastCmd.Flags().DurationVar(&flagWatch, "watch", 0, "Redraw the forecast in place every interval, e.g. 10m")
 	forecastCmd.Flags().IntVarP(&flagDays, "days", "d", 1, "Forecast days (1-3 on free tier)")
-	forecastCmd.Flags().StringVarP(&flagLang, "lang", "l", "", "Language (e.g., es, en, fr)")
-	forecastCmd.Flags().StringVar(&flagAPIKey, "apikey", "", "WeatherAPI key (or set WEATHER_API_KEY)")
 	forecastCmd.Flags().BoolVar(&flagDebug, "debug", false, "Print raw structs for debugging")
 	forecastCmd.Flags().IntVar(&flagDayIndex, "day-index", -1, "Show only this forecast day index (0..days-1)")
-	forecastCmd.Flags().BoolVar(&flagJSON, "json", false, "Print raw JSON response")
+	forecastCmd.Flags().BoolVar(&flagAQI, "aqi", false, "Include air quality (weatherapi)")
+	forecastCmd.Flags().BoolVar(&flagAlerts, "alerts", false, "Include official weather alerts (weatherapi)")
 }
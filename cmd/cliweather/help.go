@@ -0,0 +1,81 @@
+package main
+
+import (
+	"mruiz/cliWeather/internal/i18n"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// helpLocale es el idioma con el que se pinta la ayuda; se fija justo antes
+// de mostrarla, cuando ya se han leído los flags (--lang)
+var helpLocale = i18n.Default()
+
+// usageTemplate es la plantilla de uso de cobra con los títulos pasados por
+// el catálogo (función T)
+const usageTemplate = `{{T "Usage:"}}{{if .Runnable}}
+  {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
+  {{.CommandPath}} [command]{{end}}{{if gt (len .Aliases) 0}}
+
+{{T "Aliases:"}}
+  {{.NameAndAliases}}{{end}}{{if .HasExample}}
+
+{{T "Examples:"}}
+{{.Example}}{{end}}{{if .HasAvailableSubCommands}}
+
+{{T "Available Commands:"}}{{range .Commands}}{{if (or .IsAvailableCommand (eq .Name "help"))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
+
+{{T "Flags:"}}
+{{.LocalFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasAvailableInheritedFlags}}
+
+{{T "Global Flags:"}}
+{{.InheritedFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasHelpSubCommands}}
+
+{{T "Additional help topics:"}}{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+  {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
+
+{{T "Use \"%s [command] --help\" for more information about a command." .CommandPath}}{{end}}
+`
+
+func init() {
+	cobra.AddTemplateFunc("T", func(key string, a ...any) string {
+		return helpLocale.Sprintf(key, a...)
+	})
+	rootCmd.SetUsageTemplate(usageTemplate)
+
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		helpLocale = uiLocale()
+		localizeCommands(rootCmd, helpLocale)
+		defaultHelp(cmd, args)
+	})
+}
+
+// localizeCommands traduce las descripciones de cmd, sus flags y sus
+// subcomandos. Las claves del catálogo son los textos en inglés del código.
+func localizeCommands(cmd *cobra.Command, l *i18n.Locale) {
+	cmd.Short = l.Sprintf(cmd.Short)
+	cmd.Long = l.Sprintf(cmd.Long)
+
+	localizeFlag := func(f *pflag.Flag) {
+		switch f.Name {
+		case "help":
+			f.Usage = l.Sprintf("help for %s", cmd.Name())
+			return
+		case "output":
+			f.Usage = outputUsage(cmd, l)
+			return
+		case "template":
+			f.Usage = templateUsage(l)
+			return
+		}
+		f.Usage = l.Sprintf(f.Usage)
+	}
+	cmd.Flags().VisitAll(localizeFlag)
+	cmd.PersistentFlags().VisitAll(localizeFlag)
+
+	for _, sub := range cmd.Commands() {
+		localizeCommands(sub, l)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/weather"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// maxHistoryDays evita lanzar cientos de peticiones por un rango mal escrito
+const maxHistoryDays = 31
+
+var (
+	flagFrom string
+	flagTo   string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show observed weather for a date range",
+	Example: `  cliweather history --from 2026-10-01 --to 2026-10-07 -c Vigo
+  cliweather history --from 2026-10-12`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		l := uiLocale()
+
+		from, err := time.Parse(time.DateOnly, flagFrom)
+		if err != nil {
+			return errors.New(l.Sprintf("invalid --from date %q (expected YYYY-MM-DD)", flagFrom))
+		}
+		to := from
+		if flagTo != "" {
+			if to, err = time.Parse(time.DateOnly, flagTo); err != nil {
+				return errors.New(l.Sprintf("invalid --to date %q (expected YYYY-MM-DD)", flagTo))
+			}
+		}
+		if to.Before(from) {
+			return errors.New(l.Sprintf("--to (%s) is before --from (%s)", flagTo, flagFrom))
+		}
+		if n := int(to.Sub(from).Hours()/24) + 1; n > maxHistoryDays {
+			return errors.New(l.Sprintf("range too long: %d days (max %d)", n, maxHistoryDays))
+		}
+
+		opt, err := renderOptions(cfg)
+		if err != nil {
+			return err
+		}
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		g, err := granularity()
+		if err != nil {
+			return err
+		}
+		p, err := newProvider(cfg)
+		if err != nil {
+			return err
+		}
+		// Cada día lleva su propio plazo: un mes son hasta 31 peticiones
+		w, err := weather.FetchHistory(context.Background(), p, flagCity, from, to, totalTimeout(p, cfg))
+		if err != nil {
+			return err
+		}
+		summary := weather.Summarize(w.Days)
+
+		if format == "json" {
+			doc := render.NewJSONDocument(w, opt)
+			doc.Summary = render.NewJSONSummary(summary, opt.Units)
+			return render.RenderJSON(doc, os.Stdout)
+		}
+		if slices.Contains(tableFormats, format) {
+			return renderTable(format, g, w, opt, os.Stdout)
+		}
+
+		render.RenderHeader(w, os.Stdout, opt)
+		if err := render.RenderAll(w, os.Stdout, opt); err != nil {
+			return err
+		}
+		render.RenderSummary(summary, os.Stdout, opt)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	addQueryFlags(historyCmd)
+	addOutputFlag(historyCmd, "text", "json", "csv", "tsv", "ndjson")
+	addGranularityFlag(historyCmd)
+	historyCmd.Flags().StringVar(&flagFrom, "from", "", "First day (YYYY-MM-DD)")
+	historyCmd.Flags().StringVar(&flagTo, "to", "", "Last day (YYYY-MM-DD, defaults to --from)")
+	_ = historyCmd.MarkFlagRequired("from")
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/render"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var flagPlaceDefault bool
+
+var locationsCmd = &cobra.Command{
+	Use:     "locations",
+	Aliases: []string{"places"},
+	Short:   "Manage saved locations",
+	Long: `Save places under a short name and use that name anywhere a city is
+accepted (-c casa). The names are stored in the locations section of the
+config file; the default location (the location setting) may be one of them.`,
+	Example: `  cliweather locations add casa 42.23,-8.72
+  cliweather locations add oficina Santiago de Compostela --default
+  cliweather forecast -c casa
+  cliweather locations list`,
+}
+
+var locationsAddCmd = &cobra.Command{
+	Use:   "add <name> <place>",
+	Short: "Save a place under a name",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.Path(flagConfig)
+		if err != nil {
+			return err
+		}
+		alias, query := args[0], strings.Join(args[1:], " ")
+		if err := config.AddPlace(path, alias, query); err != nil {
+			return err
+		}
+		if flagPlaceDefault {
+			if err := config.Set(path, "location", strings.ToLower(alias)); err != nil {
+				return err
+			}
+			k, _ := config.Lookup("location")
+			warnOverridden(cmd, k)
+		}
+		return nil
+	},
+}
+
+var locationsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the saved locations",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(flagConfig, cmd.Flags())
+		if err != nil {
+			return err
+		}
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			doc := struct {
+				Default string         `json:"default"`
+				Places  []config.Place `json:"locations"`
+			}{cfg.Location, cfg.Places}
+			if doc.Places == nil {
+				doc.Places = []config.Place{}
+			}
+			return enc.Encode(doc)
+		}
+
+		opt, err := renderOptions(cfg)
+		if err != nil {
+			return err
+		}
+		render.RenderPlaces(cfg.Places, cfg.Location, os.Stdout, opt)
+		return nil
+	},
+}
+
+var locationsRemoveCmd = &cobra.Command{
+	Use:               "remove <name>",
+	Aliases:           []string{"rm"},
+	Short:             "Forget a saved location",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completePlaceNames(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, path, err := placesConfig(cmd)
+		if err != nil {
+			return err
+		}
+		if _, err := savedPlace(cfg, args[0]); err != nil {
+			return err
+		}
+		return config.RemovePlace(path, cfg, args[0])
+	},
+}
+
+var locationsRenameCmd = &cobra.Command{
+	Use:               "rename <old> <new>",
+	Aliases:           []string{"mv"},
+	Short:             "Rename a saved location",
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completePlaceNames(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, path, err := placesConfig(cmd)
+		if err != nil {
+			return err
+		}
+		if _, err := savedPlace(cfg, args[0]); err != nil {
+			return err
+		}
+		return config.RenamePlace(path, cfg, args[0], args[1])
+	},
+}
+
+var locationsDefaultCmd = &cobra.Command{
+	Use:               "default <name>",
+	Short:             "Use a saved location when --city is not given",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completePlaceNames(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, path, err := placesConfig(cmd)
+		if err != nil {
+			return err
+		}
+		p, err := savedPlace(cfg, args[0])
+		if err != nil {
+			return err
+		}
+		if err := config.Set(path, "location", p.Alias); err != nil {
+			return err
+		}
+		k, _ := config.Lookup("location")
+		warnOverridden(cmd, k)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(locationsCmd)
+	locationsCmd.AddCommand(locationsAddCmd, locationsListCmd, locationsRemoveCmd, locationsRenameCmd, locationsDefaultCmd)
+
+	addOutputFlag(locationsListCmd, "text", "json")
+	locationsAddCmd.Flags().BoolVar(&flagPlaceDefault, "default", false, "Also make it the default location")
+}
+
+// placesConfig carga la configuración y la ruta del fichero a editar
+func placesConfig(cmd *cobra.Command) (config.Config, string, error) {
+	cfg, err := config.Load(flagConfig, cmd.Flags())
+	if err != nil {
+		return cfg, "", err
+	}
+	path, err := config.Path(flagConfig)
+	return cfg, path, err
+}
+
+// savedPlace busca la localización guardada con ese nombre o explica cuáles
+// hay
+func savedPlace(cfg config.Config, alias string) (config.Place, error) {
+	p, ok := cfg.Place(alias)
+	if ok {
+		return p, nil
+	}
+	l := uiLocale()
+	if len(cfg.Places) == 0 {
+		return p, errors.New(l.Sprintf("no saved location named %q (there are none yet)", alias))
+	}
+	names := make([]string, len(cfg.Places))
+	for i, p := range cfg.Places {
+		names[i] = p.Alias
+	}
+	return p, errors.New(l.Sprintf("no saved location named %q (expected one of %s)", alias, strings.Join(names, ", ")))
+}
+
+// completePlaceNames completa nombres de localizaciones guardadas en los
+// primeros n argumentos
+func completePlaceNames(n int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		cfg, _ := config.Load(flagConfig, nil)
+		return completePlaces(cfg, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/weather"
+	"os"
+	"slices"
+	"text/template"
+)
+
+// forecastQueries devuelve las consultas de forecast: la de cada --city (con
+// los alias traducidos), la de cada localización guardada con
+// --all-favorites o la localización por defecto
+func forecastQueries(cfg config.Config) ([]string, error) {
+	if flagAllFavorites {
+		if len(cfg.Places) == 0 {
+			return nil, errors.New(uiLocale().Sprintf("--all-favorites needs saved locations (see cliweather locations add)"))
+		}
+		queries := make([]string, len(cfg.Places))
+		for i, p := range cfg.Places {
+			queries[i] = p.Query
+		}
+		return queries, nil
+	}
+	if len(flagCities) <= 1 {
+		return []string{flagCity}, nil
+	}
+	queries := make([]string, len(flagCities))
+	for i, c := range flagCities {
+		queries[i] = cfg.Resolve(c)
+	}
+	return queries, nil
+}
+
+// forecastMany pide en paralelo la previsión de varias localizaciones y las
+// escribe en el orden de queries
+func forecastMany(p weather.Provider, cfg config.Config, queries []string, format string, g render.Granularity, tmpl *template.Template, opt render.Options) error {
+	fetched, fetchErr := fetchMany(p, cfg, queries, opt.Locale)
+	if err := renderMany(fetched, format, g, tmpl, opt, os.Stdout); err != nil {
+		return err
+	}
+	return fetchErr
+}
+
+// fetchMany pide en paralelo la previsión de cada consulta y devuelve las
+// obtenidas en el orden de queries. Las que fallan se avisan por stderr y no
+// impiden mostrar las demás; si alguna falla devuelve además un
+// *partialError.
+func fetchMany(p weather.Provider, cfg config.Config, queries []string, l *i18n.Locale) ([]*weather.Forecast, error) {
+	reqs := make([]weather.Request, len(queries))
+	for i, q := range queries {
+		reqs[i] = weather.Request{Query: q, Days: flagDays, AQI: flagAQI, Alerts: flagAlerts}
+	}
+
+	var fetched []*weather.Forecast
+	var partial *partialError
+	for _, r := range weather.FetchMany(context.Background(), p, reqs, totalTimeout(p, cfg)) {
+		if r.Err != nil {
+			msg, _ := explainError(r.Err, l)
+			fmt.Fprintln(os.Stderr, l.Sprintf("warning: %s: %s", r.Request.Query, msg))
+			if partial == nil {
+				partial = &partialError{Total: len(reqs), Err: r.Err}
+			}
+			partial.Failed++
+			continue
+		}
+		fetched = append(fetched, r.Forecast)
+	}
+	if partial != nil {
+		return fetched, partial
+	}
+	return fetched, nil
+}
+
+// renderMany escribe varias previsiones: una lista versionada en json, una
+// sola tabla (con la columna location) en csv, tsv y ndjson, y una tras otra
+// en texto o con --template
+func renderMany(ws []*weather.Forecast, format string, g render.Granularity, tmpl *template.Template, opt render.Options, out io.Writer) error {
+	if flagDebug {
+		for _, w := range ws {
+			fmt.Printf("%+v\n\n", *w)
+		}
+	}
+
+	if format == "json" {
+		docs := make([]render.JSONDocument, len(ws))
+		for i, w := range ws {
+			docs[i] = render.NewJSONDocument(w, opt)
+		}
+		return render.RenderJSONList(docs, out)
+	}
+	if len(ws) == 0 {
+		return nil
+	}
+	if slices.Contains(tableFormats, format) {
+		var t render.Table
+		for _, w := range ws {
+			wt := render.NewTable(w, opt.Units, g)
+			t.Columns = wt.Columns
+			t.Rows = append(t.Rows, wt.Rows...)
+		}
+		return writeTable(format, t, out)
+	}
+
+	for i, w := range ws {
+		if tmpl != nil {
+			if err := render.RenderTemplate(tmpl, w, out, opt); err != nil {
+				return err
+			}
+			continue
+		}
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		if err := renderForecast(w, out, opt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
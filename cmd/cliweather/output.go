@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/weather"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// outputsAnnotation guarda en cada comando los formatos de --output que admite
+const outputsAnnotation = "cliweather_outputs"
+
+var (
+	flagOutput      string
+	flagGranularity string
+)
+
+// tableFormats son los formatos de --output que salen de render.Table
+var tableFormats = []string{"csv", "tsv", "ndjson"}
+
+// barFormats son los formatos de --output para barras de estado
+var barFormats = []string{"statusline", "waybar", "i3blocks", "polybar"}
+
+// addOutputFlag registra --output con los formatos que admite cmd (el primero
+// es el predeterminado) y --json como atajo de --output json
+func addOutputFlag(cmd *cobra.Command, formats ...string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[outputsAnnotation] = strings.Join(formats, ",")
+
+	cmd.Flags().StringVarP(&flagOutput, "output", "o", formats[0], outputUsage(cmd, i18n.New("en")))
+	cmd.Flags().BoolVar(&flagJSON, "json", false, "Shorthand for --output json")
+	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// outputFormats devuelve los formatos que admite cmd
+func outputFormats(cmd *cobra.Command) []string {
+	return strings.Split(cmd.Annotations[outputsAnnotation], ",")
+}
+
+// outputUsage describe --output en el idioma de l
+func outputUsage(cmd *cobra.Command, l *i18n.Locale) string {
+	return l.Sprintf("Output format: %s", strings.Join(outputFormats(cmd), ", "))
+}
+
+// outputFormat devuelve el formato elegido con --output o --json y comprueba
+// que cmd lo admite
+func outputFormat(cmd *cobra.Command) (string, error) {
+	format := strings.ToLower(flagOutput)
+	if flagJSON {
+		format = "json"
+	}
+	formats := outputFormats(cmd)
+	if !slices.Contains(formats, format) {
+		return "", errors.New(uiLocale().Sprintf("unsupported --output %q (expected %s)", flagOutput, strings.Join(formats, ", ")))
+	}
+	return format, nil
+}
+
+// addGranularityFlag registra --granularity para las salidas tabulares
+func addGranularityFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&flagGranularity, "granularity", string(render.Hourly), "Rows per hour or per day for csv, tsv and ndjson: hourly or daily")
+	_ = cmd.RegisterFlagCompletionFunc("granularity", cobra.FixedCompletions([]string{string(render.Hourly), string(render.Daily)}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// granularity valida --granularity en el idioma de la interfaz
+func granularity() (render.Granularity, error) {
+	g, err := render.ParseGranularity(flagGranularity)
+	if err != nil {
+		return "", errors.New(uiLocale().Sprintf("unsupported --granularity %q (expected hourly or daily)", flagGranularity))
+	}
+	return g, nil
+}
+
+// renderTable escribe w como csv, tsv o ndjson con la granularidad g
+func renderTable(format string, g render.Granularity, w *weather.Forecast, opt render.Options, out io.Writer) error {
+	return writeTable(format, render.NewTable(w, opt.Units, g), out)
+}
+
+// writeTable escribe t como csv, tsv o ndjson
+func writeTable(format string, t render.Table, out io.Writer) error {
+	switch format {
+	case "csv":
+		return render.RenderCSV(t, out, true)
+	case "tsv":
+		return render.RenderTSV(t, out, true)
+	default:
+		return render.RenderNDJSON(t, out)
+	}
+}
+
+// renderBar escribe w en el formato de barra de estado elegido. Las barras
+// ponen sus propios colores, así que nunca se emiten secuencias ANSI.
+func renderBar(format string, w *weather.Forecast, opt render.Options, out io.Writer) error {
+	opt.Color = false
+	switch format {
+	case "waybar":
+		return render.RenderWaybar(w, out, opt)
+	case "i3blocks":
+		render.RenderI3Blocks(w, out, opt)
+	case "polybar":
+		render.RenderPolybar(w, out, opt)
+	default:
+		render.RenderStatusLine(w, out, opt)
+	}
+	return nil
+}
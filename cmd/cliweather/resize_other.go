@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// notifyResize no hace nada donde no existe SIGWINCH; el siguiente refresco
+// ya se adapta al tamaño nuevo
+func notifyResize(c chan<- os.Signal) {}
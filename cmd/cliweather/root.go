@@ -1,33 +1,56 @@
 package main
 
 import (
+	"fmt"
+	"mruiz/cliWeather/internal/icons"
 	"os"
 
 	"github.com/spf13/cobra"
 )
 
+// Flags globales. Los de presentación no se leen directamente: config.Load
+// los combina con el entorno y el fichero de configuración.
 var (
-	noColor bool
-	noEmoji bool
+	flagConfig string
+	noColor    bool
+	noEmoji    bool
+	unitsFlag  string
+	windFlag   string
+	iconsFlag  string
+	iconsFile  string
 )
 
 var rootCmd = &cobra.Command{
 	Use:           "cliweather",
-	Short:         "CLI del tiempo sencilla y práctica",
+	Short:         "Simple, handy weather CLI",
 	SilenceUsage:  true,
 	SilenceErrors: true,
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		l := uiLocale()
+		msg, code := explainError(err, l)
+		fmt.Fprintln(os.Stderr, l.Sprintf("error: %s", msg))
+		os.Exit(code)
 	}
 }
 
 func init() {
 	// Flags persistentes disponibles para todos los subcomandos
-	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Desactivar colores ANSI en la salida")
-	rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "Desactivar emojis en la salida")
+	rootCmd.PersistentFlags().StringVar(&flagConfig, "config", "", "Config file in YAML or TOML (default $XDG_CONFIG_HOME/cliweather/config.yaml, or CLIWEATHER_CONFIG)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable ANSI colours in the output")
+	rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "Disable emoji in the output")
+	rootCmd.PersistentFlags().StringVar(&unitsFlag, "units", "", "Unit system: metric, imperial or uk (or WEATHER_UNITS)")
+	rootCmd.PersistentFlags().StringVar(&windFlag, "wind-unit", "", "Wind unit: kmh, mph, ms, knots or beaufort (or WEATHER_WIND_UNIT)")
+	rootCmd.PersistentFlags().StringVar(&iconsFlag, "icons", "", "Condition icon set: emoji, nerd or ascii (or WEATHER_ICONS)")
+	rootCmd.PersistentFlags().StringVar(&iconsFile, "icons-file", "", "JSON file with custom condition icons (or WEATHER_ICONS_FILE)")
+
+	_ = rootCmd.RegisterFlagCompletionFunc("icons", cobra.FixedCompletions(icons.Names(), cobra.ShellCompDirectiveNoFileComp))
+	_ = rootCmd.MarkPersistentFlagFilename("icons-file", "json")
+	_ = rootCmd.MarkPersistentFlagFilename("config", "yaml", "yml", "toml")
+	_ = rootCmd.RegisterFlagCompletionFunc("units", cobra.FixedCompletions([]string{"metric", "imperial", "uk"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = rootCmd.RegisterFlagCompletionFunc("wind-unit", cobra.FixedCompletions([]string{"kmh", "mph", "ms", "knots", "beaufort"}, cobra.ShellCompDirectiveNoFileComp))
 }
 
 // ===== Helpers de entorno para color/emoji =====
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/weather"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Las localizaciones apenas cambian: la caché de autocompletado dura más
+const searchCacheTTL = 30 * 24 * time.Hour
+
+var searchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Search locations matching a text",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		opt, err := renderOptions(cfg)
+		if err != nil {
+			return err
+		}
+		p, err := newProvider(cfg)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), totalTimeout(p, cfg))
+		defer cancel()
+
+		locs, err := weather.Search(ctx, p, strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+
+		if format == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(locs)
+		}
+		render.RenderLocations(locs, os.Stdout, opt)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	addProviderFlags(searchCmd)
+	addOutputFlag(searchCmd, "text", "json")
+}
+
+// completeCity sugiere primero los alias de las localizaciones guardadas y,
+// a partir de tres letras, localizaciones reales (ver cityCompletions).
+func completeCity(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	out := completePlaces(cfg, toComplete)
+	if len([]rune(toComplete)) < 3 {
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg.CacheTTL = searchCacheTTL
+	p, err := newProvider(cfg)
+	if err != nil {
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	locs, err := weather.Search(ctx, p, toComplete)
+	if err != nil {
+		return out, cobra.ShellCompDirectiveNoFileComp
+	}
+	return append(out, cityCompletions(locs)...), cobra.ShellCompDirectiveNoFileComp
+}
+
+// cityCompletions convierte los resultados de una búsqueda en sugerencias:
+// el nombre, que es lo que se lee y se vuelve a escribir, con la región, el
+// país y las coordenadas como descripción. Un nombre repetido llevaría al
+// primer resultado, así que las coincidencias siguientes con el mismo nombre
+// se sugieren por sus coordenadas.
+func cityCompletions(locs []weather.Location) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, l := range locs {
+		coords := fmt.Sprintf("%.4f,%.4f", l.Lat, l.Lon)
+		desc := coords
+		if detail := strings.Trim(l.Region+", "+l.Country, ", "); detail != "" {
+			desc = detail + " (" + coords + ")"
+		}
+		value := l.Name
+		if key := strings.ToLower(l.Name); value == "" || seen[key] {
+			value, desc = coords, l.Name+", "+desc
+		} else {
+			seen[key] = true
+		}
+		out = append(out, value+"\t"+desc)
+	}
+	return out
+}
+
+// completePlaces devuelve los alias guardados que empiezan por toComplete,
+// con su consulta como descripción
+func completePlaces(cfg config.Config, toComplete string) []string {
+	var out []string
+	for _, p := range cfg.Places {
+		if strings.HasPrefix(p.Alias, strings.ToLower(toComplete)) {
+			out = append(out, p.Alias+"\t"+p.Query)
+		}
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"mruiz/cliWeather/internal/weather"
+	"slices"
+	"testing"
+)
+
+func TestCityCompletions(t *testing.T) {
+	locs := []weather.Location{
+		{Name: "Santiago", Region: "Galicia", Country: "Spain", Lat: 42.8805, Lon: -8.5457},
+		{Name: "Santiago", Region: "Santiago Metropolitan", Country: "Chile", Lat: -33.4569, Lon: -70.6483},
+		{Name: "Vigo", Country: "Spain", Lat: 42.2328, Lon: -8.7226},
+	}
+	want := []string{
+		"Santiago\tGalicia, Spain (42.8805,-8.5457)",
+		"-33.4569,-70.6483\tSantiago, Santiago Metropolitan, Chile (-33.4569,-70.6483)",
+		"Vigo\tSpain (42.2328,-8.7226)",
+	}
+	if got := cityCompletions(locs); !slices.Equal(got, want) {
+		t.Errorf("cityCompletions =\n%q\nwant\n%q", got, want)
+	}
+	if got := cityCompletions(nil); len(got) != 0 {
+		t.Errorf("cityCompletions(nil) = %q, want nothing", got)
+	}
+}
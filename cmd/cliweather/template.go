@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/render"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagTemplate     string
+	flagTemplateFile string
+)
+
+// addTemplateFlags registra --template y --template-file en cmd
+func addTemplateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&flagTemplate, "template", "", templateUsage(i18n.New("en")))
+	cmd.Flags().StringVar(&flagTemplateFile, "template-file", "", "File with a Go text/template to render")
+	cmd.MarkFlagsMutuallyExclusive("template", "template-file")
+	_ = cmd.MarkFlagFilename("template-file")
+	_ = cmd.RegisterFlagCompletionFunc("template", cobra.FixedCompletions(render.TemplateNames(), cobra.ShellCompDirectiveNoFileComp))
+}
+
+// templateUsage describe --template en el idioma de l
+func templateUsage(l *i18n.Locale) string {
+	return l.Sprintf("Go text/template to render, inline or a built-in name: %s", strings.Join(render.TemplateNames(), ", "))
+}
+
+// loadTemplate compila la plantilla de --template o --template-file, o
+// devuelve nil si no se ha pedido ninguna. Una plantilla en línea que no
+// termina en salto de línea recibe uno, para que cada ejecución sea una línea.
+func loadTemplate(format string) (*template.Template, error) {
+	if flagTemplate == "" && flagTemplateFile == "" {
+		return nil, nil
+	}
+	l := uiLocale()
+	if format != "text" {
+		return nil, errors.New(l.Sprintf("--template cannot be combined with --output %s", format))
+	}
+
+	name, text := "--template", flagTemplate
+	switch {
+	case flagTemplateFile != "":
+		b, err := os.ReadFile(flagTemplateFile)
+		if err != nil {
+			return nil, err
+		}
+		name, text = flagTemplateFile, string(b)
+	case render.Templates[flagTemplate] != "":
+		name, text = flagTemplate, render.Templates[flagTemplate]
+	case !strings.HasSuffix(text, "\n"):
+		text += "\n"
+	}
+
+	t, err := render.ParseTemplate(name, text)
+	if err != nil {
+		return nil, errors.New(l.Sprintf("invalid template: %v", err))
+	}
+	return t, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"mruiz/cliWeather/internal/tui"
+	"mruiz/cliWeather/internal/weather"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// flagTUIDays es propio de tui porque su valor por defecto (3) no es el de
+// forecast. El ajuste days lo toma como valor por defecto (FlagDefault), así
+// que cfg.Days ya tiene -d, el entorno, el fichero o los 3 días.
+var flagTUIDays int
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [location...]",
+	Short: "Browse days, hours and locations interactively",
+	Long: `Interactive view with a location list (by default the default location
+and the saved ones), one tab per day, a scrollable hourly table and
+temperature, rain and wind charts. Keys: ↑/↓ or j/k scroll hours, ←/→ or
+h/l switch days, Tab/Shift-Tab switch locations, u cycles units, r refreshes
+and q, Esc or Ctrl-C quits.`,
+	ValidArgsFunction: completeCity,
+	Example: `  cliweather tui
+  cliweather tui Vigo Madrid Lisbon -d 3`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		opt, err := renderOptions(cfg)
+		if err != nil {
+			return err
+		}
+		if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+			return errors.New(opt.Locale.Sprintf("tui needs a terminal"))
+		}
+
+		p, err := newProvider(cfg)
+		if err != nil {
+			return err
+		}
+		fresh, err := buildProvider(cfg, true)
+		if err != nil {
+			return err
+		}
+		timeout := totalTimeout(p, cfg)
+		fetch := func(ctx context.Context, query string, refresh bool) (*weather.Forecast, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			prov := p
+			if refresh {
+				prov = fresh
+			}
+			w, err := prov.Forecast(ctx, weather.Request{Query: cfg.Resolve(query), Days: cfg.Days, AQI: flagAQI})
+			if err != nil {
+				msg, _ := explainError(err, opt.Locale)
+				return nil, errors.New(msg)
+			}
+			return w, nil
+		}
+
+		// Sin argumentos: la localización por defecto y las guardadas, que se
+		// muestran por su alias
+		locations := args
+		if len(locations) == 0 {
+			locations = []string{cfg.Location}
+			for _, p := range cfg.Places {
+				if !strings.EqualFold(p.Alias, cfg.Location) {
+					locations = append(locations, p.Alias)
+				}
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+		defer stop()
+		resize := make(chan os.Signal, 1)
+		notifyResize(resize)
+		defer signal.Stop(resize)
+		return tui.Run(ctx, tui.New(locations, cfg.Units, opt), fetch, resize)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+
+	addQueryFlags(tuiCmd)
+	tuiCmd.Flags().IntVarP(&flagTUIDays, "days", "d", 3, "Forecast days (1-3 on free tier)")
+	tuiCmd.Flags().BoolVar(&flagAQI, "aqi", false, "Include air quality (weatherapi)")
+}
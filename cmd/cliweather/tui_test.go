@@ -0,0 +1,53 @@
+package main
+
+import (
+	"mruiz/cliWeather/internal/config"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTUIDays(t *testing.T) {
+	// Sin fichero, .env ni ajustes de cliweather en el entorno
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, "CLIWEATHER_") || strings.HasPrefix(name, "WEATHER_") {
+			t.Setenv(name, "")
+		}
+	}
+
+	days := func(args ...string) int {
+		t.Helper()
+		defer func() {
+			flagTUIDays = 3
+			tuiCmd.Flags().Lookup("days").Changed = false
+		}()
+		if err := tuiCmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := config.Load(flagConfig, tuiCmd.Flags())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cfg.Days
+	}
+
+	if got := days(); got != 3 {
+		t.Errorf("sin -d ni days: %d días, want 3", got)
+	}
+	t.Setenv("CLIWEATHER_DAYS", "2")
+	if got := days(); got != 2 {
+		t.Errorf("days en el entorno: %d días, want 2", got)
+	}
+	if got := days("-d", "1"); got != 1 {
+		t.Errorf("-d 1 con days en el entorno: %d días, want 1", got)
+	}
+}
@@ -14,7 +14,7 @@ var (
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
-	Short: "Muestra la versión del binario",
+	Short: "Show the binary version",
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("cliweather %s (commit %s, built %s)\n", version, commit, date)
 	},
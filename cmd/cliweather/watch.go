@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/weather"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	// minWatchInterval evita machacar la API con intervalos de segundos
+	minWatchInterval = 10 * time.Second
+	// Reintentos tras un error: 30s, 1m, 2m... hasta maxBackoff
+	firstBackoff = 30 * time.Second
+	maxBackoff   = 15 * time.Minute
+)
+
+// Secuencias ANSI de la pantalla alternativa
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l\x1b[?7l" // pantalla alternativa, sin cursor ni autowrap
+	leaveScreen = "\x1b[?7h\x1b[?25h\x1b[?1049l"
+	cursorHome  = "\x1b[H"
+	clearLine   = "\x1b[K"
+	clearBelow  = "\x1b[J"
+)
+
+var flagInterval time.Duration
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Refresh the forecast in place on an interval",
+	Example: `  cliweather watch -c Vigo
+  cliweather watch -c Vigo -d 3 --interval 30m
+  cliweather forecast -c Vigo --watch 10m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+		flagDays = cfg.Days
+		opt, err := renderOptions(cfg)
+		if err != nil {
+			return err
+		}
+		if opt.Chart, err = chartKind(); err != nil {
+			return err
+		}
+		p, err := newProvider(watchConfig(cfg, flagInterval))
+		if err != nil {
+			return err
+		}
+		req := weather.Request{Query: flagCity, Days: flagDays, AQI: flagAQI, Alerts: flagAlerts}
+		return runWatch(flagInterval, p, req, totalTimeout(p, cfg), renderForecast, opt)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	addQueryFlags(watchCmd)
+	watchCmd.Flags().DurationVarP(&flagInterval, "interval", "i", 10*time.Minute, "Time between refreshes, e.g. 5m or 1h")
+	watchCmd.Flags().IntVarP(&flagDays, "days", "d", 1, "Forecast days (1-3 on free tier)")
+	watchCmd.Flags().IntVar(&flagDayIndex, "day-index", -1, "Show only this forecast day index (0..days-1)")
+	watchCmd.Flags().BoolVar(&flagAQI, "aqi", false, "Include air quality (weatherapi)")
+	watchCmd.Flags().BoolVar(&flagAlerts, "alerts", false, "Include official weather alerts (weatherapi)")
+	addChartFlag(watchCmd)
+}
+
+// watcher guarda el estado de una sesión de watch entre refrescos
+type watcher struct {
+	out  io.Writer
+	l    *i18n.Locale
+	opt  render.Options
+	draw func(*weather.Forecast, io.Writer, render.Options) error
+
+	data      *weather.Forecast // últimos datos buenos
+	updated   time.Time         // cuándo se obtuvieron
+	prev, cur string            // texto del refresco anterior y del actual
+	err       error             // último error, nil si el último refresco fue bien
+	next      time.Time         // próximo refresco
+}
+
+// runWatch pide la previsión cada interval y la redibuja en la pantalla
+// alternativa hasta Ctrl-C. Si la petición falla se conserva lo último que
+// se obtuvo, se marca como desactualizado y se reintenta con espera
+// creciente. Un cambio de tamaño de la terminal solo redibuja.
+func runWatch(interval time.Duration, p weather.Provider, req weather.Request, timeout time.Duration,
+	draw func(*weather.Forecast, io.Writer, render.Options) error, opt render.Options) error {
+	l := opt.Locale
+	if interval < minWatchInterval {
+		return errors.New(l.Sprintf("refresh interval must be at least %s", fmtDuration(minWatchInterval)))
+	}
+	if !isTerminal(os.Stdout) {
+		return errors.New(l.Sprintf("watch needs a terminal"))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+	defer signal.Stop(resize)
+
+	fmt.Fprint(os.Stdout, enterScreen)
+	defer fmt.Fprint(os.Stdout, leaveScreen)
+
+	w := &watcher{out: os.Stdout, l: l, opt: opt, draw: draw}
+	failures := 0
+	for {
+		fctx, cancel := context.WithTimeout(ctx, timeout)
+		data, err := p.Forecast(fctx, req)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		delay := interval
+		if err != nil {
+			failures++
+			delay = backoff(failures)
+		} else {
+			failures = 0
+		}
+		w.update(data, err, time.Now().Add(delay))
+		w.render()
+
+		// El pie lleva la cuenta atrás, así que se repinta cada segundo
+		timer := time.NewTimer(delay)
+		tick := time.NewTicker(time.Second)
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				tick.Stop()
+				return nil
+			case <-resize:
+				w.render()
+			case <-tick.C:
+				w.render()
+			case <-timer.C:
+				break wait
+			}
+		}
+		tick.Stop()
+	}
+}
+
+// watchConfig acorta la caché de respuestas a interval: con el TTL normal
+// cada refresco redibujaría la misma respuesta guardada mientras el pie dice
+// que se acaba de actualizar
+func watchConfig(cfg config.Config, interval time.Duration) config.Config {
+	cfg.CacheTTL = min(cfg.CacheTTL, interval)
+	return cfg
+}
+
+// backoff devuelve la espera tras n errores seguidos
+func backoff(n int) time.Duration {
+	d := firstBackoff
+	for i := 1; i < n && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
+
+// update registra el resultado de un refresco. Con error se mantienen los
+// datos anteriores.
+func (w *watcher) update(data *weather.Forecast, err error, next time.Time) {
+	w.err, w.next = err, next
+	if err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	if derr := w.draw(data, &buf, w.opt); derr != nil {
+		w.err = derr
+		return
+	}
+	w.data, w.updated = data, time.Now()
+	w.prev, w.cur = w.cur, buf.String()
+}
+
+// render pinta el último texto, con los cambios resaltados si hay color, y
+// una línea de estado al pie, recortado a la altura de la terminal
+func (w *watcher) render() {
+	body := w.cur
+	if w.opt.Color {
+		body = render.HighlightChanges(w.prev, w.cur)
+	}
+	lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+	if _, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil && height > 2 && len(lines) > height-2 {
+		lines = lines[:height-2]
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(cursorHome)
+	for _, line := range lines {
+		buf.WriteString(line + clearLine + "\n")
+	}
+	buf.WriteString(clearLine + "\n" + w.status() + clearLine + clearBelow)
+	_, _ = w.out.Write(buf.Bytes())
+}
+
+// status es la línea del pie: hora del último dato y próximo refresco, o el
+// error y desde cuándo está desactualizado lo que se ve
+func (w *watcher) status() string {
+	in := fmtDuration(time.Until(w.next))
+	var s, color string
+	switch {
+	case w.err == nil:
+		s = w.l.Sprintf("Updated %s · next refresh in %s · Ctrl-C to quit", w.updated.Format("15:04:05"), in)
+		color = "\x1b[90m" // gris
+	case w.data == nil:
+		msg, _ := explainError(w.err, w.l)
+		s = w.l.Sprintf("No data yet · %s · retrying in %s", msg, in)
+		color = "\x1b[33m" // amarillo
+	default:
+		msg, _ := explainError(w.err, w.l)
+		s = w.l.Sprintf("Stale: last update %s (%s ago) · %s · retrying in %s",
+			w.updated.Format("15:04:05"), fmtDuration(time.Since(w.updated)), msg, in)
+		color = "\x1b[33m"
+	}
+	if !w.opt.Color {
+		return s
+	}
+	return color + s + "\x1b[0m"
+}
+
+// fmtDuration redondea d a segundos y quita los ceros finales: 10m, 1h30m, 45s
+func fmtDuration(d time.Duration) string {
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
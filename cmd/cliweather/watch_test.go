@@ -0,0 +1,21 @@
+package main
+
+import (
+	"mruiz/cliWeather/internal/config"
+	"testing"
+	"time"
+)
+
+func TestWatchConfig_CacheTTL(t *testing.T) {
+	tests := []struct{ ttl, interval, want time.Duration }{
+		{10 * time.Minute, time.Minute, time.Minute},
+		{10 * time.Minute, time.Hour, 10 * time.Minute},
+		{0, time.Minute, 0},
+	}
+	for _, tt := range tests {
+		cfg := watchConfig(config.Config{CacheTTL: tt.ttl}, tt.interval)
+		if cfg.CacheTTL != tt.want {
+			t.Errorf("TTL %s, interval %s: got %s, want %s", tt.ttl, tt.interval, cfg.CacheTTL, tt.want)
+		}
+	}
+}
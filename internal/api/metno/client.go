@@ -0,0 +1,112 @@
+// Package metno implementa el proveedor MET Norway (api.met.no), gratuito y
+// sin API key. Solo acepta coordenadas, así que las consultas por nombre se
+// resuelven con un weather.Geocoder.
+package metno
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/cache"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/weather"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const baseURL = "https://api.met.no/weatherapi/locationforecast/2.0/complete"
+
+type Client struct {
+	http  *http.Client
+	geo   weather.Geocoder
+	lang  string // MET Norway solo da códigos; el texto se traduce con i18n
+	cache *cache.Cache
+}
+
+func NewClient(geo weather.Geocoder, lang string, timeout time.Duration) *Client {
+	return &Client{
+		http: &http.Client{Timeout: timeout},
+		geo:  geo,
+		lang: lang,
+	}
+}
+
+// WithCache activa la caché de respuestas. Con nil se desactiva.
+func (c *Client) WithCache(store *cache.Cache) *Client {
+	c.cache = store
+	return c
+}
+
+func (c *Client) Name() string { return "metno" }
+
+func (c *Client) Forecast(ctx context.Context, req weather.Request) (*weather.Forecast, error) {
+	loc, err := c.geo.Geocode(ctx, req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	// MET Norway pide no usar más de 4 decimales
+	lat := strconv.FormatFloat(loc.Lat, 'f', 4, 64)
+	lon := strconv.FormatFloat(loc.Lon, 'f', 4, 64)
+	u, _ := url.Parse(baseURL)
+	q := u.Query()
+	q.Set("lat", lat)
+	q.Set("lon", lon)
+	u.RawQuery = q.Encode()
+
+	body, err := c.get(ctx, u.String(), cache.Key("metno-forecast", lat, lon))
+	if err != nil {
+		return nil, err
+	}
+	var resp locationForecast
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	f := toForecast(&resp, loc, req.Days)
+	f.Provider = c.Name()
+	f.TranslateConditions(i18n.New(c.lang).Text)
+	return f, nil
+}
+
+// Search delega en el geocodificador, si este sabe buscar
+func (c *Client) Search(ctx context.Context, text string) ([]weather.Location, error) {
+	if s, ok := c.geo.(weather.Searcher); ok {
+		return s.Search(ctx, text)
+	}
+	return nil, fmt.Errorf("metno: search: %w", weather.ErrNotSupported)
+}
+
+// get hace la petición GET, consultando antes la caché (si está activa) y
+// guardando en ella las respuestas correctas.
+func (c *Client) get(ctx context.Context, rawURL, key string) ([]byte, error) {
+	if body, ok := c.cache.Get(key); ok {
+		return body, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Los términos de uso de MET Norway exigen un User-Agent identificable
+	req.Header.Set("User-Agent", "weather-cli/1.0 (+github.com/titorspace/cliweather)")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, &weather.HTTPError{Provider: "metno", Status: resp.StatusCode}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.cache.Put(key, body)
+	return body, nil
+}
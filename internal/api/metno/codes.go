@@ -0,0 +1,52 @@
+package metno
+
+import "strings"
+
+// symbolToCondition traduce los symbol_code de MET Norway (sin el sufijo
+// _day/_night/_polartwilight) a la numeración de condiciones de WeatherAPI.
+var symbolToCondition = map[string]int{
+	"clearsky":          1000,
+	"fair":              1003,
+	"partlycloudy":      1003,
+	"cloudy":            1006,
+	"fog":               1135,
+	"lightrain":         1183,
+	"rain":              1189,
+	"heavyrain":         1195,
+	"lightrainshowers":  1240,
+	"rainshowers":       1243,
+	"heavyrainshowers":  1246,
+	"lightsleet":        1204,
+	"sleet":             1207,
+	"heavysleet":        1207,
+	"lightsleetshowers": 1249,
+	"sleetshowers":      1252,
+	"heavysleetshowers": 1252,
+	"lightsnow":         1213,
+	"snow":              1219,
+	"heavysnow":         1225,
+	"lightsnowshowers":  1255,
+	"snowshowers":       1258,
+	"heavysnowshowers":  1258,
+}
+
+// parseSymbol devuelve el código de condición y si es de día
+func parseSymbol(symbol string) (code int, isDay bool) {
+	base, variant, _ := strings.Cut(symbol, "_")
+	isDay = variant != "night"
+
+	if c, ok := symbolToCondition[base]; ok {
+		return c, isDay
+	}
+	if strings.Contains(base, "thunder") {
+		switch {
+		case strings.Contains(base, "snow"), strings.Contains(base, "sleet"):
+			return 1282, isDay
+		case strings.HasPrefix(base, "light"):
+			return 1273, isDay
+		default:
+			return 1276, isDay
+		}
+	}
+	return 1003, isDay
+}
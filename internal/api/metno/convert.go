@@ -0,0 +1,103 @@
+package metno
+
+import (
+	"math"
+	"mruiz/cliWeather/internal/weather"
+	"time"
+)
+
+const msToKph = 3.6
+
+// toForecast convierte la serie temporal de MET Norway al modelo neutral,
+// agrupando los pasos por fecha local y quedándose con los primeros days días.
+func toForecast(r *locationForecast, loc weather.Location, days int) *weather.Forecast {
+	tz := time.Local
+	if loc.TimeZone != "" {
+		if l, err := time.LoadLocation(loc.TimeZone); err == nil {
+			tz = l
+		}
+	}
+
+	f := &weather.Forecast{Location: loc}
+	ts := r.Properties.Timeseries
+	if len(ts) == 0 {
+		return f
+	}
+
+	first := toHour(ts[0])
+	inst := ts[0].Data.Instant.Details
+	f.Current = weather.Current{
+		Time:       first.Time,
+		TempC:      first.TempC,
+		FeelsLikeC: first.FeelsLikeC,
+		Condition:  first.Condition,
+		WindKph:    first.WindKph,
+		WindDegree: first.WindDegree,
+		WindDir:    first.WindDir,
+		GustKph:    inst.WindSpeedOfGust * msToKph,
+		Humidity:   first.Humidity,
+		PressureMb: inst.AirPressureAtSeaLevel,
+		PrecipMm:   first.PrecipMm,
+		Cloud:      int(math.Round(inst.CloudAreaFraction)),
+		UV:         inst.UltravioletIndexClearSky,
+	}
+
+	var current string
+	var hours []weather.Hour
+	var uv float64
+	flush := func() {
+		if len(hours) == 0 {
+			return
+		}
+		day := weather.AggregateDay(hours)
+		// La fecha del día es la medianoche local, no la hora UTC del primer
+		// paso, que al este de UTC puede caer aún en el día anterior
+		day.Date, _ = time.ParseInLocation(time.DateOnly, current, tz)
+		day.UV = uv
+		f.Days = append(f.Days, day)
+		hours, uv = nil, 0
+	}
+
+	for _, step := range ts {
+		date := step.Time.In(tz).Format(time.DateOnly)
+		if date != current {
+			flush()
+			if len(f.Days) == days {
+				break
+			}
+			current = date
+		}
+		hours = append(hours, toHour(step))
+		uv = math.Max(uv, step.Data.Instant.Details.UltravioletIndexClearSky)
+	}
+	if len(f.Days) < days {
+		flush()
+	}
+	return f
+}
+
+func toHour(step timestep) weather.Hour {
+	d := step.Data.Instant.Details
+	h := weather.Hour{
+		Time:       step.Time,
+		TempC:      d.AirTemperature,
+		FeelsLikeC: d.AirTemperature,
+		WindKph:    d.WindSpeed * msToKph,
+		WindDegree: int(math.Round(d.WindFromDirection)),
+		WindDir:    weather.CompassDir(int(math.Round(d.WindFromDirection))),
+		Humidity:   int(math.Round(d.RelativeHumidity)),
+	}
+
+	// Más allá de ~2 días solo hay periodos de 6 horas
+	p := step.Data.Next1Hours
+	if p == nil {
+		p = step.Data.Next6Hours
+	}
+	if p != nil {
+		code, isDay := parseSymbol(p.Summary.SymbolCode)
+		h.Condition = weather.NewCondition(code, isDay)
+		h.PrecipMm = p.Details.PrecipitationAmount
+		h.ChanceOfRain = p.Details.ProbabilityOfPrecipitation
+	}
+	return h
+}
@@ -0,0 +1,113 @@
+package metno
+
+import (
+	"encoding/json"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/weather"
+	"testing"
+	"time"
+)
+
+const samplePayload = `{
+  "properties": {
+    "timeseries": [
+      {
+        "time": "2025-09-15T20:00:00Z",
+        "data": {
+          "instant": {"details": {"air_temperature": 16.0, "relative_humidity": 90, "wind_speed": 2.0,
+            "wind_from_direction": 180, "wind_speed_of_gust": 4.0, "air_pressure_at_sea_level": 1020,
+            "cloud_area_fraction": 50, "ultraviolet_index_clear_sky": 0}},
+          "next_1_hours": {"summary": {"symbol_code": "partlycloudy_night"},
+            "details": {"precipitation_amount": 0, "probability_of_precipitation": 5}}
+        }
+      },
+      {
+        "time": "2025-09-15T21:00:00Z",
+        "data": {
+          "instant": {"details": {"air_temperature": 15.0, "relative_humidity": 94, "wind_speed": 3.0,
+            "wind_from_direction": 200}},
+          "next_1_hours": {"summary": {"symbol_code": "lightrainshowersandthunder_night"},
+            "details": {"precipitation_amount": 1.2, "probability_of_precipitation": 60}}
+        }
+      },
+      {
+        "time": "2025-09-15T23:00:00Z",
+        "data": {
+          "instant": {"details": {"air_temperature": 14.0, "relative_humidity": 96, "wind_speed": 1.0}},
+          "next_6_hours": {"summary": {"symbol_code": "cloudy"},
+            "details": {"precipitation_amount": 0.4, "probability_of_precipitation": 30}}
+        }
+      }
+    ]
+  }
+}`
+
+func TestToForecast(t *testing.T) {
+	var resp locationForecast
+	if err := json.Unmarshal([]byte(samplePayload), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	loc := weather.Location{Name: "Vigo", TimeZone: "Europe/Madrid"}
+
+	f := toForecast(&resp, loc, 3)
+	// 23:00Z ya es el día 16 en Madrid
+	if len(f.Days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(f.Days))
+	}
+	if f.Current.WindKph != 7.2 || f.Current.GustKph != 14.4 || f.Current.WindDir != "S" {
+		t.Errorf("unexpected current wind: %+v", f.Current)
+	}
+	d0 := f.Days[0]
+	if d0.MaxTempC != 16 || d0.MinTempC != 15 || d0.ChanceOfRain != 60 || d0.TotalPrecipMm != 1.2 {
+		t.Errorf("unexpected aggregation: %+v", d0)
+	}
+	if c := d0.Hours[1].Condition; c.Code != 1273 || c.IsDay {
+		t.Errorf("unexpected thunder condition: %+v", c)
+	}
+	if c := f.Days[1].Hours[0].Condition; c.Code != 1006 || !c.IsDay {
+		t.Errorf("6h period condition not used: %+v", c)
+	}
+
+	if f := toForecast(&resp, loc, 1); len(f.Days) != 1 {
+		t.Fatalf("expected days limit to apply, got %d", len(f.Days))
+	}
+}
+
+// Al este de UTC los pasos de la tarde UTC ya son del día siguiente: la fecha
+// de cada día tiene que ser la local
+func TestToForecast_LocalDates(t *testing.T) {
+	step := func(ts string) timestep {
+		var s timestep
+		if err := json.Unmarshal([]byte(`{"time": "`+ts+`", "data": {"instant": {"details": {"air_temperature": 10}}}}`), &s); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	var resp locationForecast
+	for _, ts := range []string{"2026-10-18T18:00:00Z", "2026-10-18T23:00:00Z", "2026-10-19T12:00:00Z", "2026-10-19T22:00:00Z"} {
+		resp.Properties.Timeseries = append(resp.Properties.Timeseries, step(ts))
+	}
+
+	f := toForecast(&resp, weather.Location{Name: "Oslo", TimeZone: "Europe/Oslo"}, 3)
+	want := []string{"2026-10-18", "2026-10-19", "2026-10-20"}
+	if len(f.Days) != len(want) {
+		t.Fatalf("expected %d days, got %d", len(want), len(f.Days))
+	}
+	for i, d := range f.Days {
+		if got := d.Date.Format(time.DateOnly); got != want[i] || d.Date.Hour() != 0 || d.Date.Location().String() != "Europe/Oslo" {
+			t.Errorf("day %d: date %v, want local midnight of %s", i, d.Date, want[i])
+		}
+	}
+}
+
+// Todos los símbolos de MET Norway llevan a un texto que está en el catálogo
+func TestSymbols_Translated(t *testing.T) {
+	es := i18n.New("es")
+	for symbol, code := range symbolToCondition {
+		for _, isDay := range []bool{true, false} {
+			if text := weather.NewCondition(code, isDay).Text; es.Text(text) == text {
+				t.Errorf("symbol %s (%s) has no translation", symbol, text)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package metno
+
+import "time"
+
+// Tipos de respuesta de Locationforecast 2.0 ("complete")
+// https://api.met.no/weatherapi/locationforecast/2.0/documentation
+
+type locationForecast struct {
+	Properties struct {
+		Timeseries []timestep `json:"timeseries"`
+	} `json:"properties"`
+}
+
+type timestep struct {
+	Time time.Time `json:"time"`
+	Data struct {
+		Instant struct {
+			Details instantDetails `json:"details"`
+		} `json:"instant"`
+		Next1Hours *period `json:"next_1_hours"`
+		Next6Hours *period `json:"next_6_hours"`
+	} `json:"data"`
+}
+
+type instantDetails struct {
+	AirTemperature           float64 `json:"air_temperature"`
+	AirPressureAtSeaLevel    float64 `json:"air_pressure_at_sea_level"`
+	CloudAreaFraction        float64 `json:"cloud_area_fraction"`
+	RelativeHumidity         float64 `json:"relative_humidity"`
+	WindFromDirection        float64 `json:"wind_from_direction"`
+	WindSpeed                float64 `json:"wind_speed"`         // m/s
+	WindSpeedOfGust          float64 `json:"wind_speed_of_gust"` // m/s
+	UltravioletIndexClearSky float64 `json:"ultraviolet_index_clear_sky"`
+}
+
+type period struct {
+	Summary struct {
+		SymbolCode string `json:"symbol_code"`
+	} `json:"summary"`
+	Details struct {
+		PrecipitationAmount        float64 `json:"precipitation_amount"`
+		ProbabilityOfPrecipitation float64 `json:"probability_of_precipitation"`
+	} `json:"details"`
+}
@@ -0,0 +1,172 @@
+// Package openmeteo implementa el proveedor Open-Meteo (https://open-meteo.com),
+// que no necesita API key. Incluye también su servicio de geocodificación,
+// que reutilizan otros proveedores que solo aceptan coordenadas.
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/cache"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/weather"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	forecastURL  = "https://api.open-meteo.com/v1/forecast"
+	geocodingURL = "https://geocoding-api.open-meteo.com/v1/search"
+)
+
+const (
+	currentVars = "temperature_2m,apparent_temperature,relative_humidity_2m,is_day,precipitation," +
+		"weather_code,cloud_cover,pressure_msl,wind_speed_10m,wind_direction_10m,wind_gusts_10m,uv_index,visibility"
+	hourlyVars = "temperature_2m,apparent_temperature,relative_humidity_2m,precipitation_probability," +
+		"precipitation,snowfall,weather_code,wind_speed_10m,wind_direction_10m,is_day"
+	dailyVars = "weather_code,temperature_2m_max,temperature_2m_min,sunrise,sunset,precipitation_sum," +
+		"snowfall_sum,precipitation_probability_max,wind_speed_10m_max,uv_index_max"
+)
+
+type Client struct {
+	http  *http.Client
+	lang  string
+	cache *cache.Cache
+}
+
+func NewClient(lang string, timeout time.Duration) *Client {
+	return &Client{
+		http: &http.Client{Timeout: timeout},
+		lang: lang,
+	}
+}
+
+// WithCache activa la caché de respuestas. Con nil se desactiva.
+func (c *Client) WithCache(store *cache.Cache) *Client {
+	c.cache = store
+	return c
+}
+
+func (c *Client) Name() string { return "openmeteo" }
+
+// Geocode resuelve un nombre de lugar (o unas coordenadas "lat,lon")
+func (c *Client) Geocode(ctx context.Context, query string) (weather.Location, error) {
+	if lat, lon, ok := weather.ParseCoords(query); ok {
+		return weather.Location{Name: query, Lat: lat, Lon: lon}, nil
+	}
+
+	locs, err := c.geocode(ctx, query, 1)
+	if err != nil {
+		return weather.Location{}, err
+	}
+	if len(locs) == 0 {
+		return weather.Location{}, fmt.Errorf("openmeteo: %q: %w", query, weather.ErrLocationNotFound)
+	}
+	return locs[0], nil
+}
+
+// Search devuelve hasta 10 localizaciones cuyo nombre encaja con text
+func (c *Client) Search(ctx context.Context, text string) ([]weather.Location, error) {
+	return c.geocode(ctx, text, 10)
+}
+
+func (c *Client) geocode(ctx context.Context, name string, count int) ([]weather.Location, error) {
+	u, _ := url.Parse(geocodingURL)
+	q := u.Query()
+	q.Set("name", name)
+	q.Set("count", strconv.Itoa(count))
+	q.Set("language", c.lang)
+	q.Set("format", "json")
+	u.RawQuery = q.Encode()
+
+	body, err := c.get(ctx, u.String(), cache.Key("openmeteo-geocode", name, strconv.Itoa(count), c.lang))
+	if err != nil {
+		return nil, err
+	}
+	var resp geocodingResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	locs := make([]weather.Location, len(resp.Results))
+	for i, r := range resp.Results {
+		locs[i] = weather.Location{
+			Name:     r.Name,
+			Region:   r.Admin1,
+			Country:  r.Country,
+			Lat:      r.Latitude,
+			Lon:      r.Longitude,
+			TimeZone: r.Timezone,
+		}
+	}
+	return locs, nil
+}
+
+func (c *Client) Forecast(ctx context.Context, req weather.Request) (*weather.Forecast, error) {
+	loc, err := c.Geocode(ctx, req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	lat := strconv.FormatFloat(loc.Lat, 'f', 4, 64)
+	lon := strconv.FormatFloat(loc.Lon, 'f', 4, 64)
+	u, _ := url.Parse(forecastURL)
+	q := u.Query()
+	q.Set("latitude", lat)
+	q.Set("longitude", lon)
+	q.Set("current", currentVars)
+	q.Set("hourly", hourlyVars)
+	q.Set("daily", dailyVars)
+	q.Set("forecast_days", strconv.Itoa(req.Days))
+	q.Set("timezone", "auto")
+	q.Set("timeformat", "unixtime")
+	q.Set("wind_speed_unit", "kmh")
+	u.RawQuery = q.Encode()
+
+	body, err := c.get(ctx, u.String(), cache.Key("openmeteo-forecast", lat, lon, strconv.Itoa(req.Days)))
+	if err != nil {
+		return nil, err
+	}
+	var resp forecastResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	f := toForecast(&resp, loc)
+	f.Provider = c.Name()
+	f.TranslateConditions(i18n.New(c.lang).Text)
+	return f, nil
+}
+
+// get hace la petición GET, consultando antes la caché (si está activa) y
+// guardando en ella las respuestas correctas.
+func (c *Client) get(ctx context.Context, rawURL, key string) ([]byte, error) {
+	if body, ok := c.cache.Get(key); ok {
+		return body, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "weather-cli/1.0 (+github.com/titorspace/cliweather)")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, &weather.HTTPError{Provider: "openmeteo", Status: resp.StatusCode}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = c.cache.Put(key, body)
+	return body, nil
+}
@@ -0,0 +1,42 @@
+package openmeteo
+
+// wmoToCondition traduce los códigos WMO de Open-Meteo a la numeración de
+// condiciones de WeatherAPI que usa el modelo neutral.
+// https://open-meteo.com/en/docs#weathervariables
+var wmoToCondition = map[int]int{
+	0:  1000, // despejado
+	1:  1003, // mayormente despejado
+	2:  1003, // parcialmente nuboso
+	3:  1009, // cubierto
+	45: 1135, // niebla
+	48: 1147, // niebla engelante
+	51: 1150, // llovizna débil
+	53: 1153, // llovizna moderada
+	55: 1153, // llovizna intensa
+	56: 1168, // llovizna helada
+	57: 1171, // llovizna helada intensa
+	61: 1183, // lluvia débil
+	63: 1189, // lluvia moderada
+	65: 1195, // lluvia intensa
+	66: 1198, // lluvia helada débil
+	67: 1201, // lluvia helada intensa
+	71: 1213, // nieve débil
+	73: 1219, // nieve moderada
+	75: 1225, // nieve intensa
+	77: 1237, // granos de nieve
+	80: 1240, // chubascos débiles
+	81: 1243, // chubascos moderados
+	82: 1246, // chubascos torrenciales
+	85: 1255, // chubascos de nieve débiles
+	86: 1258, // chubascos de nieve intensos
+	95: 1276, // tormenta
+	96: 1276, // tormenta con granizo débil
+	99: 1276, // tormenta con granizo fuerte
+}
+
+func conditionCode(wmo int) int {
+	if c, ok := wmoToCondition[wmo]; ok {
+		return c
+	}
+	return 1003
+}
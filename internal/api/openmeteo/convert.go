@@ -0,0 +1,115 @@
+package openmeteo
+
+import (
+	"math"
+	"mruiz/cliWeather/internal/weather"
+	"time"
+)
+
+// toForecast convierte la respuesta de Open-Meteo al modelo neutral
+func toForecast(r *forecastResponse, loc weather.Location) *weather.Forecast {
+	tz, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		tz = time.FixedZone(r.Timezone, r.UTCOffsetSeconds)
+	}
+	if loc.TimeZone == "" {
+		loc.TimeZone = r.Timezone
+	}
+
+	cur := r.Current
+	f := &weather.Forecast{
+		Location: loc,
+		Current: weather.Current{
+			Time:       time.Unix(cur.Time, 0),
+			TempC:      cur.Temperature,
+			FeelsLikeC: cur.ApparentTemperature,
+			Condition:  weather.NewCondition(conditionCode(cur.WeatherCode), cur.IsDay == 1),
+			WindKph:    cur.WindSpeed,
+			WindDegree: int(cur.WindDirection),
+			WindDir:    weather.CompassDir(int(cur.WindDirection)),
+			GustKph:    cur.WindGusts,
+			Humidity:   int(cur.RelativeHumidity),
+			PressureMb: cur.PressureMSL,
+			PrecipMm:   cur.Precipitation,
+			Cloud:      int(cur.CloudCover),
+			UV:         cur.UVIndex,
+			VisKm:      cur.Visibility / 1000,
+		},
+	}
+
+	// Agrupamos las horas por fecha local
+	hoursByDate := map[string][]weather.Hour{}
+	hr := r.Hourly
+	for i, ts := range hr.Time {
+		t := time.Unix(ts, 0)
+		h := weather.Hour{
+			Time:         t,
+			TempC:        at(hr.Temperature, i),
+			FeelsLikeC:   at(hr.ApparentTemperature, i),
+			Condition:    weather.NewCondition(conditionCode(atInt(hr.WeatherCode, i)), atInt(hr.IsDay, i) == 1),
+			WindKph:      at(hr.WindSpeed, i),
+			WindDegree:   int(at(hr.WindDirection, i)),
+			WindDir:      weather.CompassDir(int(at(hr.WindDirection, i))),
+			Humidity:     int(at(hr.RelativeHumidity, i)),
+			PrecipMm:     at(hr.Precipitation, i),
+			ChanceOfRain: at(hr.PrecipitationProbability, i),
+		}
+		if at(hr.Snowfall, i) > 0 {
+			h.ChanceOfSnow = h.ChanceOfRain
+		}
+		key := t.In(tz).Format(time.DateOnly)
+		hoursByDate[key] = append(hoursByDate[key], h)
+	}
+
+	dl := r.Daily
+	for i, ts := range dl.Time {
+		date := time.Unix(ts, 0).In(tz)
+		day := weather.AggregateDay(hoursByDate[date.Format(time.DateOnly)])
+		day.Date = date
+		day.MaxTempC = at(dl.TemperatureMax, i)
+		day.MinTempC = at(dl.TemperatureMin, i)
+		day.MaxWindKph = at(dl.WindSpeedMax, i)
+		day.TotalPrecipMm = at(dl.PrecipitationSum, i)
+		day.TotalSnowCm = at(dl.SnowfallSum, i)
+		day.ChanceOfRain = int(math.Round(at(dl.PrecipitationProbabilityMax, i)))
+		day.UV = at(dl.UVIndexMax, i)
+		day.Condition = weather.NewCondition(conditionCode(atInt(dl.WeatherCode, i)), true)
+		day.Astro = weather.Astro{
+			Sunrise: formatClock(atInt64(dl.Sunrise, i), tz),
+			Sunset:  formatClock(atInt64(dl.Sunset, i), tz),
+		}
+		f.Days = append(f.Days, day)
+	}
+	return f
+}
+
+// Los arrays de Open-Meteo pueden venir más cortos (o con null) si una
+// variable no está disponible; estas funciones devuelven cero en ese caso.
+
+func at(xs []float64, i int) float64 {
+	if i < len(xs) {
+		return xs[i]
+	}
+	return 0
+}
+
+func atInt(xs []int, i int) int {
+	if i < len(xs) {
+		return xs[i]
+	}
+	return 0
+}
+
+func atInt64(xs []int64, i int) int64 {
+	if i < len(xs) {
+		return xs[i]
+	}
+	return 0
+}
+
+func formatClock(ts int64, tz *time.Location) string {
+	if ts == 0 {
+		return ""
+	}
+	return time.Unix(ts, 0).In(tz).Format("03:04 PM")
+}
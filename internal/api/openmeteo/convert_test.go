@@ -0,0 +1,111 @@
+package openmeteo
+
+import (
+	"encoding/json"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/weather"
+	"testing"
+)
+
+// 2025-09-15 00:00 y 01:00 en Europe/Madrid (UTC+2), más el día siguiente
+const samplePayload = `{
+  "timezone": "Europe/Madrid",
+  "utc_offset_seconds": 7200,
+  "current": {
+    "time": 1757920200, "temperature_2m": 16.1, "apparent_temperature": 15.0,
+    "relative_humidity_2m": 95, "is_day": 1, "precipitation": 0.1, "weather_code": 45,
+    "cloud_cover": 80, "pressure_msl": 1023.4, "wind_speed_10m": 7.2,
+    "wind_direction_10m": 331, "wind_gusts_10m": 9.9, "uv_index": 0.1, "visibility": 300
+  },
+  "hourly": {
+    "time": [1757887200, 1757890800, 1757973600],
+    "temperature_2m": [16.4, 16.1, 14.0],
+    "apparent_temperature": [16.0, 15.8, 13.0],
+    "relative_humidity_2m": [92, 94, 80],
+    "precipitation_probability": [0, 40, 10],
+    "precipitation": [0, 0.3, 0],
+    "snowfall": [0, 0, 0],
+    "weather_code": [0, 61, 3],
+    "wind_speed_10m": [2.9, 12.0, 5.0],
+    "wind_direction_10m": [137, 71, 180],
+    "is_day": [0, 0, 0]
+  },
+  "daily": {
+    "time": [1757887200, 1757973600],
+    "weather_code": [61, 3],
+    "temperature_2m_max": [20.1, 19.0],
+    "temperature_2m_min": [14.2, 13.5],
+    "sunrise": [1757916900, 1758003360],
+    "sunset": [1757961840, 1758048120],
+    "precipitation_sum": [0.3, 0],
+    "snowfall_sum": [0, 0],
+    "precipitation_probability_max": [40, 10],
+    "wind_speed_10m_max": [20.2, 15.0],
+    "uv_index_max": [5.1, 4.0]
+  }
+}`
+
+func TestToForecast(t *testing.T) {
+	var resp forecastResponse
+	if err := json.Unmarshal([]byte(samplePayload), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	f := toForecast(&resp, weather.Location{Name: "Vigo", Country: "Spain"})
+
+	if f.Location.TimeZone != "Europe/Madrid" {
+		t.Errorf("expected timezone from response, got %q", f.Location.TimeZone)
+	}
+	if f.Current.Condition.Code != 1135 || f.Current.Condition.Text != "Fog" {
+		t.Errorf("unexpected current condition: %+v", f.Current.Condition)
+	}
+	if f.Current.WindDir != "NNW" || f.Current.VisKm != 0.3 {
+		t.Errorf("unexpected current wind/visibility: %+v", f.Current)
+	}
+
+	if len(f.Days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(f.Days))
+	}
+	d0 := f.Days[0]
+	if len(d0.Hours) != 2 || len(f.Days[1].Hours) != 1 {
+		t.Fatalf("hours not grouped by local date: %d / %d", len(d0.Hours), len(f.Days[1].Hours))
+	}
+	if d0.MaxTempC != 20.1 || d0.MinTempC != 14.2 || d0.MaxWindKph != 20.2 || d0.ChanceOfRain != 40 {
+		t.Errorf("daily values not taken from daily block: %+v", d0)
+	}
+	if d0.Astro.Sunrise != "08:15 AM" {
+		t.Errorf("unexpected sunrise %q", d0.Astro.Sunrise)
+	}
+	if h := d0.Hours[1]; h.Condition.Code != 1183 || h.Condition.IsDay {
+		t.Errorf("unexpected hour condition: %+v", h.Condition)
+	}
+}
+
+// Open-Meteo solo da el código: el texto se traduce con el catálogo
+func TestToForecast_TranslatedConditions(t *testing.T) {
+	var resp forecastResponse
+	if err := json.Unmarshal([]byte(samplePayload), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	f := toForecast(&resp, weather.Location{Name: "Vigo", Country: "Spain"})
+	f.TranslateConditions(i18n.New("es").Text)
+
+	if got := f.Current.Condition.Text; got != "Niebla" {
+		t.Errorf("current condition = %q, want Niebla", got)
+	}
+	if got := f.Days[0].Condition.Text; got != "Lluvia ligera" {
+		t.Errorf("day condition = %q, want Lluvia ligera", got)
+	}
+	if got := f.Days[0].Hours[0].Condition.Text; got != "Despejado" {
+		t.Errorf("night hour condition = %q, want Despejado", got)
+	}
+
+	// Todos los códigos a los que se traduce WMO tienen texto en el catálogo
+	es := i18n.New("es")
+	for wmo, code := range wmoToCondition {
+		for _, isDay := range []bool{true, false} {
+			if text := weather.NewCondition(code, isDay).Text; es.Text(text) == text {
+				t.Errorf("WMO %d (%s) has no translation", wmo, text)
+			}
+		}
+	}
+}
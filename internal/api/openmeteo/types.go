@@ -0,0 +1,64 @@
+package openmeteo
+
+// Tipos de respuesta de la API de Open-Meteo (con timeformat=unixtime)
+
+type geocodingResponse struct {
+	Results []struct {
+		Name      string  `json:"name"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Country   string  `json:"country"`
+		Admin1    string  `json:"admin1"`
+		Timezone  string  `json:"timezone"`
+	} `json:"results"`
+}
+
+type forecastResponse struct {
+	Timezone         string `json:"timezone"`
+	UTCOffsetSeconds int    `json:"utc_offset_seconds"`
+
+	Current struct {
+		Time                int64   `json:"time"`
+		Temperature         float64 `json:"temperature_2m"`
+		ApparentTemperature float64 `json:"apparent_temperature"`
+		RelativeHumidity    float64 `json:"relative_humidity_2m"`
+		IsDay               int     `json:"is_day"`
+		Precipitation       float64 `json:"precipitation"`
+		WeatherCode         int     `json:"weather_code"`
+		CloudCover          float64 `json:"cloud_cover"`
+		PressureMSL         float64 `json:"pressure_msl"`
+		WindSpeed           float64 `json:"wind_speed_10m"`
+		WindDirection       float64 `json:"wind_direction_10m"`
+		WindGusts           float64 `json:"wind_gusts_10m"`
+		UVIndex             float64 `json:"uv_index"`
+		Visibility          float64 `json:"visibility"` // metros
+	} `json:"current"`
+
+	Hourly struct {
+		Time                     []int64   `json:"time"`
+		Temperature              []float64 `json:"temperature_2m"`
+		ApparentTemperature      []float64 `json:"apparent_temperature"`
+		RelativeHumidity         []float64 `json:"relative_humidity_2m"`
+		PrecipitationProbability []float64 `json:"precipitation_probability"`
+		Precipitation            []float64 `json:"precipitation"`
+		Snowfall                 []float64 `json:"snowfall"`
+		WeatherCode              []int     `json:"weather_code"`
+		WindSpeed                []float64 `json:"wind_speed_10m"`
+		WindDirection            []float64 `json:"wind_direction_10m"`
+		IsDay                    []int     `json:"is_day"`
+	} `json:"hourly"`
+
+	Daily struct {
+		Time                        []int64   `json:"time"`
+		WeatherCode                 []int     `json:"weather_code"`
+		TemperatureMax              []float64 `json:"temperature_2m_max"`
+		TemperatureMin              []float64 `json:"temperature_2m_min"`
+		Sunrise                     []int64   `json:"sunrise"`
+		Sunset                      []int64   `json:"sunset"`
+		PrecipitationSum            []float64 `json:"precipitation_sum"`
+		SnowfallSum                 []float64 `json:"snowfall_sum"`
+		PrecipitationProbabilityMax []float64 `json:"precipitation_probability_max"`
+		WindSpeedMax                []float64 `json:"wind_speed_10m_max"`
+		UVIndexMax                  []float64 `json:"uv_index_max"`
+	} `json:"daily"`
+}
@@ -3,65 +3,156 @@ package weatherapi
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/cache"
 	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 )
 
-const baseURL = "https://api.weatherapi.com/v1/forecast.json"
+const defaultBaseURL = "https://api.weatherapi.com/v1"
 
 type Client struct {
 	http    *http.Client
+	baseURL string // se cambia en los tests para apuntar a un servidor local
 	apiKey  string
 	lang    string
 	timeout time.Duration
+	cache   *cache.Cache
 }
 
 func NewClient(apikey, lang string, timeout time.Duration) *Client {
 	return &Client{
 		http:    &http.Client{Timeout: timeout},
+		baseURL: defaultBaseURL,
 		apiKey:  apikey,
 		lang:    lang,
 		timeout: timeout,
 	}
 }
 
+// WithCache activa la caché de respuestas. Con nil se desactiva.
+func (c *Client) WithCache(store *cache.Cache) *Client {
+	c.cache = store
+	return c
+}
+
 func (c *Client) Forecast(ctx context.Context, query string, days int, aqi, alerts bool) (*Weather, error) {
-	u, _ := url.Parse(baseURL)
-	q := u.Query()
-	q.Set("key", c.apiKey)
+	q := url.Values{}
 	q.Set("q", query)
 	q.Set("days", strconv.Itoa(days))
-	q.Set("lang", c.lang)
 	q.Set("aqi", boolToYesNo(aqi))
 	q.Set("alerts", boolToYesNo(alerts))
-	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	key := cache.Key("forecast", query, strconv.Itoa(days), c.lang, boolToYesNo(aqi), boolToYesNo(alerts))
+	body, err := c.get(ctx, c.endpoint("forecast.json", q), key)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "weather-cli/1.0 (+github.com/titorspace/cliweather)")
 
-	resp, err := c.http.Do(req)
+	var w Weather
+	if err := json.Unmarshal(body, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Current consulta current.json: solo localización y condiciones actuales
+// (el bloque Forecast del resultado queda vacío).
+func (c *Client) Current(ctx context.Context, query string, aqi bool) (*Weather, error) {
+	q := url.Values{}
+	q.Set("q", query)
+	q.Set("aqi", boolToYesNo(aqi))
+
+	key := cache.Key("current", query, c.lang, boolToYesNo(aqi))
+	body, err := c.get(ctx, c.endpoint("current.json", q), key)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("weatherapi: http %d", resp.StatusCode)
+	var w Weather
+	if err := json.Unmarshal(body, &w); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// History consulta history.json para una fecha concreta (en la zona horaria
+// de la localización). La respuesta trae un único forecastday.
+func (c *Client) History(ctx context.Context, query string, date time.Time) (*Weather, error) {
+	dt := date.Format(time.DateOnly)
+	q := url.Values{}
+	q.Set("q", query)
+	q.Set("dt", dt)
+
+	body, err := c.get(ctx, c.endpoint("history.json", q), cache.Key("history", query, dt, c.lang))
+	if err != nil {
+		return nil, err
 	}
 
 	var w Weather
-	if err := json.NewDecoder(resp.Body).Decode(&w); err != nil {
+	if err := json.Unmarshal(body, &w); err != nil {
 		return nil, err
 	}
 	return &w, nil
 }
 
+// Search consulta search.json y devuelve las localizaciones que encajan con text
+func (c *Client) Search(ctx context.Context, text string) ([]SearchResult, error) {
+	q := url.Values{}
+	q.Set("q", text)
+
+	body, err := c.get(ctx, c.endpoint("search.json", q), cache.Key("search", text, c.lang))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// endpoint construye la URL de un método de la API añadiendo key y lang
+func (c *Client) endpoint(method string, q url.Values) string {
+	q.Set("key", c.apiKey)
+	q.Set("lang", c.lang)
+	return c.baseURL + "/" + method + "?" + q.Encode()
+}
+
+// get hace la petición GET, consultando antes la caché (si está activa) y
+// guardando en ella las respuestas correctas.
+func (c *Client) get(ctx context.Context, rawURL, key string) ([]byte, error) {
+	if body, ok := c.cache.Get(key); ok {
+		return body, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "weather-cli/1.0 (+github.com/titorspace/cliweather)")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, decodeError(resp.StatusCode, body)
+	}
+	// La caché es best-effort: un fallo al escribir no debe romper el comando
+	_ = c.cache.Put(key, body)
+	return body, nil
+}
+
 func boolToYesNo(b bool) string {
 	if b {
 		return "yes"
@@ -0,0 +1,65 @@
+package weatherapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// searchServer responde a search.json como WeatherAPI y comprueba la consulta
+func searchServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/search.json" || q.Get("key") != "secret" || q.Get("lang") != "es" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch q.Get("q") {
+		case "santiago":
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "name": "Santiago", "region": "Region Metropolitana", "country": "Chile", "lat": -33.45, "lon": -70.67, "url": "santiago-chile"},
+				{"id": 2, "name": "Santiago De Compostela", "region": "Galicia", "country": "Spain", "lat": 42.88, "lon": -8.54, "url": "santiago-spain"}
+			]`))
+		case "zzz":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":2006,"message":"API key is invalid."}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_Search(t *testing.T) {
+	c := NewClient("secret", "es", time.Second)
+	c.baseURL = searchServer(t).URL
+
+	results, err := c.Search(context.Background(), "santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[1].Name != "Santiago De Compostela" || results[1].Region != "Galicia" || results[1].Lat != 42.88 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	// El proveedor lo pasa al modelo neutral
+	locs, err := NewProvider(c).Search(context.Background(), "santiago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locs[0].Name != "Santiago" || locs[0].Country != "Chile" || locs[0].Lon != -70.67 {
+		t.Errorf("unexpected location: %+v", locs[0])
+	}
+
+	if results, err := c.Search(context.Background(), "zzz"); err != nil || len(results) != 0 {
+		t.Errorf("no matches: got %+v, %v", results, err)
+	}
+	if _, err := c.Search(context.Background(), "fail"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("expected ErrInvalidKey, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package weatherapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mruiz/cliWeather/internal/weather"
+	"net/http"
+)
+
+// Errores centinela para usar con errors.Is sobre un *APIError.
+// Los códigos son los documentados por WeatherAPI:
+// https://www.weatherapi.com/docs/#intro-error-codes
+var (
+	ErrMissingKey       = errors.New("weatherapi: API key not provided")
+	ErrInvalidKey       = errors.New("weatherapi: API key is invalid")
+	ErrKeyDisabled      = errors.New("weatherapi: API key has been disabled")
+	ErrQuotaExceeded    = errors.New("weatherapi: monthly call quota exceeded")
+	ErrAccessDenied     = errors.New("weatherapi: API key has no access to this resource")
+	ErrLocationNotFound = errors.New("weatherapi: no matching location found")
+	ErrBadRequest       = errors.New("weatherapi: invalid request")
+	ErrInternal         = errors.New("weatherapi: internal application error")
+)
+
+var codeErrors = map[int]error{
+	1002: ErrMissingKey,
+	1003: ErrBadRequest, // parámetro q vacío
+	1005: ErrBadRequest, // URL de la petición inválida
+	1006: ErrLocationNotFound,
+	2006: ErrInvalidKey,
+	2007: ErrQuotaExceeded,
+	2008: ErrKeyDisabled,
+	2009: ErrAccessDenied,
+	9000: ErrBadRequest, // cuerpo JSON inválido (bulk)
+	9001: ErrBadRequest, // demasiadas localizaciones (bulk)
+	9999: ErrInternal,
+}
+
+// APIError es el error devuelto por el cliente cuando WeatherAPI responde
+// con un estado distinto de 2xx.
+type APIError struct {
+	Status  int    // estado HTTP
+	Code    int    // código de error de WeatherAPI (0 si el cuerpo no lo trae)
+	Message string // mensaje devuelto por WeatherAPI
+}
+
+func (e *APIError) Error() string {
+	if e.Code == 0 {
+		return fmt.Sprintf("weatherapi: http %d", e.Status)
+	}
+	return fmt.Sprintf("weatherapi: http %d: %s (code %d)", e.Status, e.Message, e.Code)
+}
+
+// Is permite comparar un *APIError con los errores centinela del paquete
+// (y con weather.ErrLocationNotFound, común a todos los proveedores).
+func (e *APIError) Is(target error) bool {
+	if target == weather.ErrLocationNotFound {
+		return e.Code == 1006
+	}
+	if sentinel, ok := codeErrors[e.Code]; ok {
+		return sentinel == target
+	}
+	// Sin código reconocible caemos al estado HTTP
+	switch {
+	case e.Status == http.StatusUnauthorized:
+		return target == ErrInvalidKey
+	case e.Status >= 500:
+		return target == ErrInternal
+	}
+	return false
+}
+
+// decodeError construye un *APIError a partir de una respuesta no-2xx.
+// El cuerpo tiene la forma {"error":{"code":1006,"message":"..."}}.
+func decodeError(status int, body []byte) error {
+	var payload struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	apiErr := &APIError{Status: status}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Code = payload.Error.Code
+		apiErr.Message = payload.Error.Message
+	}
+	return apiErr
+}
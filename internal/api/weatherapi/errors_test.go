@@ -0,0 +1,44 @@
+package weatherapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecodeError_Sentinels(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{400, `{"error":{"code":1006,"message":"No matching location found."}}`, ErrLocationNotFound},
+		{401, `{"error":{"code":2006,"message":"API key is invalid."}}`, ErrInvalidKey},
+		{403, `{"error":{"code":2007,"message":"API key has exceeded calls per month quota."}}`, ErrQuotaExceeded},
+		{403, `{"error":{"code":2008,"message":"API key has been disabled."}}`, ErrKeyDisabled},
+		{401, `{"error":{"code":1002,"message":"API key not provided."}}`, ErrMissingKey},
+		{401, `not json`, ErrInvalidKey},
+		{502, ``, ErrInternal},
+	}
+
+	for _, tc := range cases {
+		err := decodeError(tc.status, []byte(tc.body))
+		if !errors.Is(err, tc.want) {
+			t.Errorf("status %d body %q: expected errors.Is(%v), got %v", tc.status, tc.body, tc.want, err)
+		}
+	}
+}
+
+func TestDecodeError_Fields(t *testing.T) {
+	err := decodeError(400, []byte(`{"error":{"code":1006,"message":"No matching location found."}}`))
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Status != 400 || apiErr.Code != 1006 || apiErr.Message != "No matching location found." {
+		t.Fatalf("unexpected fields: %+v", apiErr)
+	}
+	if errors.Is(err, ErrQuotaExceeded) {
+		t.Fatal("location error must not match quota sentinel")
+	}
+}
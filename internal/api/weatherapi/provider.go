@@ -0,0 +1,188 @@
+package weatherapi
+
+import (
+	"context"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+	"time"
+)
+
+// Provider adapta Client a la interfaz weather.Provider
+type Provider struct {
+	client *Client
+}
+
+func NewProvider(c *Client) *Provider {
+	return &Provider{client: c}
+}
+
+func (p *Provider) Name() string { return "weatherapi" }
+
+func (p *Provider) Forecast(ctx context.Context, req weather.Request) (*weather.Forecast, error) {
+	w, err := p.client.Forecast(ctx, req.Query, req.Days, req.AQI, req.Alerts)
+	if err != nil {
+		return nil, err
+	}
+	f := toForecast(w)
+	f.Provider = p.Name()
+	return f, nil
+}
+
+// Current usa current.json, más ligero que pedir la previsión de un día
+func (p *Provider) Current(ctx context.Context, req weather.Request) (*weather.Forecast, error) {
+	w, err := p.client.Current(ctx, req.Query, req.AQI)
+	if err != nil {
+		return nil, err
+	}
+	f := toForecast(w)
+	f.Provider = p.Name()
+	return f, nil
+}
+
+func (p *Provider) History(ctx context.Context, query string, date time.Time) (*weather.Forecast, error) {
+	w, err := p.client.History(ctx, query, date)
+	if err != nil {
+		return nil, err
+	}
+	f := toForecast(w)
+	f.Provider = p.Name()
+	return f, nil
+}
+
+func (p *Provider) Search(ctx context.Context, text string) ([]weather.Location, error) {
+	results, err := p.client.Search(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	locs := make([]weather.Location, len(results))
+	for i, r := range results {
+		locs[i] = weather.Location{Name: r.Name, Region: r.Region, Country: r.Country, Lat: r.Lat, Lon: r.Lon}
+	}
+	return locs, nil
+}
+
+// toForecast convierte la respuesta de WeatherAPI al modelo neutral
+func toForecast(w *Weather) *weather.Forecast {
+	tz, err := time.LoadLocation(w.Location.TzID)
+	if err != nil {
+		tz = time.Local
+	}
+
+	c := w.Current
+	f := &weather.Forecast{
+		Location: weather.Location{
+			Name:     w.Location.Name,
+			Region:   w.Location.Region,
+			Country:  w.Location.Country,
+			Lat:      w.Location.Lat,
+			Lon:      w.Location.Lon,
+			TimeZone: w.Location.TzID,
+		},
+		Current: weather.Current{
+			Time:       time.Unix(int64(c.LastUpdatedEpoch), 0),
+			TempC:      c.TempC,
+			FeelsLikeC: c.FeelslikeC,
+			Condition:  toCondition(c.Condition, c.IsDay),
+			WindKph:    c.WindKph,
+			WindDegree: c.WindDegree,
+			WindDir:    c.WindDir,
+			GustKph:    c.GustKph,
+			Humidity:   c.Humidity,
+			PressureMb: c.PressureMb,
+			PrecipMm:   c.PrecipMm,
+			Cloud:      c.Cloud,
+			UV:         c.UV,
+			VisKm:      c.VisKm,
+			AirQuality: toAirQuality(c.AirQuality),
+		},
+	}
+
+	for _, fd := range w.Forecast.Forecastday {
+		f.Days = append(f.Days, toDay(fd, tz))
+	}
+	for _, a := range w.Alerts.Alert {
+		f.Alerts = append(f.Alerts, toAlert(a))
+	}
+	return f
+}
+
+func toDay(fd ForecastDay, tz *time.Location) weather.Day {
+	day := weather.Day{
+		MaxTempC:      fd.Day.MaxtempC,
+		MinTempC:      fd.Day.MintempC,
+		AvgTempC:      fd.Day.AvgtempC,
+		MaxWindKph:    fd.Day.MaxwindKph,
+		TotalPrecipMm: fd.Day.TotalprecipMm,
+		TotalSnowCm:   fd.Day.TotalsnowCm,
+		AvgHumidity:   fd.Day.Avghumidity,
+		ChanceOfRain:  fd.Day.DailyChanceOfRain,
+		ChanceOfSnow:  fd.Day.DailyChanceOfSnow,
+		UV:            fd.Day.UV,
+		Condition:     toCondition(fd.Day.Condition, 1),
+		AirQuality:    toAirQuality(fd.Day.AirQuality),
+		Astro: weather.Astro{
+			Sunrise: fd.Astro.Sunrise,
+			Sunset:  fd.Astro.Sunset,
+		},
+	}
+	if d, err := time.ParseInLocation(time.DateOnly, fd.Date, tz); err == nil {
+		day.Date = d
+	}
+
+	for _, h := range fd.Hour {
+		day.Hours = append(day.Hours, weather.Hour{
+			Time:         time.Unix(int64(h.TimeEpoch), 0),
+			TempC:        h.TempC,
+			FeelsLikeC:   h.FeelslikeC,
+			Condition:    toCondition(h.Condition, h.IsDay),
+			WindKph:      h.WindKph,
+			WindDegree:   h.WindDegree,
+			WindDir:      h.WindDir,
+			Humidity:     h.Humidity,
+			PrecipMm:     h.PrecipMm,
+			ChanceOfRain: h.ChanceOfRain,
+			ChanceOfSnow: h.ChanceOfSnow,
+		})
+	}
+	if day.Date.IsZero() && len(day.Hours) > 0 {
+		day.Date = day.Hours[0].Time
+	}
+	return day
+}
+
+func toCondition(c Condition, isDay int) weather.Condition {
+	return weather.Condition{Text: strings.TrimSpace(c.Text), Code: c.Code, IsDay: isDay == 1}
+}
+
+func toAirQuality(aq *AirQuality) *weather.AirQuality {
+	if aq == nil {
+		return nil
+	}
+	return &weather.AirQuality{
+		CO:           aq.CO,
+		NO2:          aq.NO2,
+		O3:           aq.O3,
+		SO2:          aq.SO2,
+		PM25:         aq.PM25,
+		PM10:         aq.PM10,
+		USEPAIndex:   aq.USEPAIndex,
+		GBDefraIndex: aq.GBDefraIndex,
+	}
+}
+
+func toAlert(a Alert) weather.Alert {
+	// Fechas mal formadas quedan a cero: el aviso sigue siendo útil
+	effective, _ := time.Parse(time.RFC3339, a.Effective)
+	expires, _ := time.Parse(time.RFC3339, a.Expires)
+	return weather.Alert{
+		Headline:    a.Headline,
+		Event:       a.Event,
+		Severity:    a.Severity,
+		Urgency:     a.Urgency,
+		Areas:       a.Areas,
+		Effective:   effective,
+		Expires:     expires,
+		Description: a.Desc,
+		Instruction: a.Instruction,
+	}
+}
@@ -1,41 +1,200 @@
 package weatherapi
 
+// Tipos de la respuesta de forecast.json. Cubren todos los campos de la
+// API (ver weather.json en la raíz del repo como ejemplo completo).
+
 type Weather struct {
-	Location struct {
-		Name    string `json:"name"`
-		Country string `json:"country"`
-	} `json:"location"`
-
-	Current struct {
-		LastUpdatedEpoch int     `json:"last_updated_epoch"`
-		TempC            float64 `json:"temp_c"`
-		Condition        struct {
-			Text string `json:"text"`
-		} `json:"condition"`
-		WindKph  float64 `json:"wind_kph"`
-		Humidity int     `json:"humidity"`
-	}
-
-	Forecast struct {
-		Forecastday []struct {
-			Day struct {
-				MaxtempC          float64 `json:"maxtemp_c"`
-				MintempC          float64 `json:"mintemp_c"`
-				DailyChanceOfRain int     `json:"daily_chance_of_rain"`
-				DailyWillItRain   int     `json:"daily_will_it_rain"`
-			} `json:"day"`
-			Astro struct {
-				Sunrise string `json:"sunrise"`
-				Sunset  string `json:"sunset"`
-			} `json:"astro"`
-			Hour []struct {
-				TimeEpoch int     `json:"time_epoch"`
-				TempC     float64 `json:"temp_c"`
-				Condition struct {
-					Text string `json:"text"`
-				} `json:"condition"`
-				ChanceOfRain float64 `json:"chance_of_rain"`
-			}
-		} `json:"forecastday"`
-	} `json:"forecast"`
+	Location Location `json:"location"`
+	Current  Current  `json:"current"`
+	Forecast Forecast `json:"forecast"`
+	Alerts   Alerts   `json:"alerts"` // solo con alerts=yes
+}
+
+type Location struct {
+	Name           string  `json:"name"`
+	Region         string  `json:"region"`
+	Country        string  `json:"country"`
+	Lat            float64 `json:"lat"`
+	Lon            float64 `json:"lon"`
+	TzID           string  `json:"tz_id"`
+	LocaltimeEpoch int64   `json:"localtime_epoch"`
+	Localtime      string  `json:"localtime"` // "2025-09-15 09:11"
+}
+
+type Condition struct {
+	Text string `json:"text"`
+	Icon string `json:"icon"`
+	Code int    `json:"code"`
+}
+
+type Current struct {
+	LastUpdatedEpoch int       `json:"last_updated_epoch"`
+	LastUpdated      string    `json:"last_updated"`
+	TempC            float64   `json:"temp_c"`
+	TempF            float64   `json:"temp_f"`
+	IsDay            int       `json:"is_day"`
+	Condition        Condition `json:"condition"`
+	WindMph          float64   `json:"wind_mph"`
+	WindKph          float64   `json:"wind_kph"`
+	WindDegree       int       `json:"wind_degree"`
+	WindDir          string    `json:"wind_dir"`
+	PressureMb       float64   `json:"pressure_mb"`
+	PressureIn       float64   `json:"pressure_in"`
+	PrecipMm         float64   `json:"precip_mm"`
+	PrecipIn         float64   `json:"precip_in"`
+	Humidity         int       `json:"humidity"`
+	Cloud            int       `json:"cloud"`
+	FeelslikeC       float64   `json:"feelslike_c"`
+	FeelslikeF       float64   `json:"feelslike_f"`
+	WindchillC       float64   `json:"windchill_c"`
+	WindchillF       float64   `json:"windchill_f"`
+	HeatindexC       float64   `json:"heatindex_c"`
+	HeatindexF       float64   `json:"heatindex_f"`
+	DewpointC        float64   `json:"dewpoint_c"`
+	DewpointF        float64   `json:"dewpoint_f"`
+	VisKm            float64   `json:"vis_km"`
+	VisMiles         float64   `json:"vis_miles"`
+	UV               float64   `json:"uv"`
+	GustMph          float64   `json:"gust_mph"`
+	GustKph          float64   `json:"gust_kph"`
+	ShortRad         float64   `json:"short_rad"`
+	DiffRad          float64   `json:"diff_rad"`
+	DNI              float64   `json:"dni"`
+	GTI              float64   `json:"gti"`
+
+	AirQuality *AirQuality `json:"air_quality,omitempty"` // solo con aqi=yes
+}
+
+// AirQuality son las concentraciones de contaminantes (μg/m³) y los índices
+// US-EPA (1 bueno .. 6 peligroso) y UK DEFRA (1 .. 10).
+type AirQuality struct {
+	CO           float64 `json:"co"`
+	NO2          float64 `json:"no2"`
+	O3           float64 `json:"o3"`
+	SO2          float64 `json:"so2"`
+	PM25         float64 `json:"pm2_5"`
+	PM10         float64 `json:"pm10"`
+	USEPAIndex   int     `json:"us-epa-index"`
+	GBDefraIndex int     `json:"gb-defra-index"`
+}
+
+type Alerts struct {
+	Alert []Alert `json:"alert"`
+}
+
+// Alert es un aviso meteorológico oficial. Las fechas vienen en ISO 8601.
+type Alert struct {
+	Headline    string `json:"headline"`
+	MsgType     string `json:"msgtype"`
+	Severity    string `json:"severity"`
+	Urgency     string `json:"urgency"`
+	Areas       string `json:"areas"`
+	Category    string `json:"category"`
+	Certainty   string `json:"certainty"`
+	Event       string `json:"event"`
+	Note        string `json:"note"`
+	Effective   string `json:"effective"`
+	Expires     string `json:"expires"`
+	Desc        string `json:"desc"`
+	Instruction string `json:"instruction"`
+}
+
+type Forecast struct {
+	Forecastday []ForecastDay `json:"forecastday"`
+}
+
+type ForecastDay struct {
+	Date      string `json:"date"` // "2025-09-15", en la zona de la localización
+	DateEpoch int64  `json:"date_epoch"`
+	Day       Day    `json:"day"`
+	Astro     Astro  `json:"astro"`
+	Hour      []Hour `json:"hour"`
+}
+
+type Day struct {
+	MaxtempC          float64   `json:"maxtemp_c"`
+	MaxtempF          float64   `json:"maxtemp_f"`
+	MintempC          float64   `json:"mintemp_c"`
+	MintempF          float64   `json:"mintemp_f"`
+	AvgtempC          float64   `json:"avgtemp_c"`
+	AvgtempF          float64   `json:"avgtemp_f"`
+	MaxwindMph        float64   `json:"maxwind_mph"`
+	MaxwindKph        float64   `json:"maxwind_kph"`
+	TotalprecipMm     float64   `json:"totalprecip_mm"`
+	TotalprecipIn     float64   `json:"totalprecip_in"`
+	TotalsnowCm       float64   `json:"totalsnow_cm"`
+	AvgvisKm          float64   `json:"avgvis_km"`
+	AvgvisMiles       float64   `json:"avgvis_miles"`
+	Avghumidity       int       `json:"avghumidity"`
+	DailyWillItRain   int       `json:"daily_will_it_rain"`
+	DailyChanceOfRain int       `json:"daily_chance_of_rain"`
+	DailyWillItSnow   int       `json:"daily_will_it_snow"`
+	DailyChanceOfSnow int       `json:"daily_chance_of_snow"`
+	Condition         Condition `json:"condition"`
+	UV                float64   `json:"uv"`
+
+	AirQuality *AirQuality `json:"air_quality,omitempty"` // solo con aqi=yes
+}
+
+type Astro struct {
+	Sunrise          string `json:"sunrise"` // "08:15 AM"
+	Sunset           string `json:"sunset"`
+	Moonrise         string `json:"moonrise"`
+	Moonset          string `json:"moonset"`
+	MoonPhase        string `json:"moon_phase"`
+	MoonIllumination int    `json:"moon_illumination"`
+	IsMoonUp         int    `json:"is_moon_up"`
+	IsSunUp          int    `json:"is_sun_up"`
+}
+
+type Hour struct {
+	TimeEpoch    int       `json:"time_epoch"`
+	Time         string    `json:"time"` // "2025-09-15 00:00"
+	TempC        float64   `json:"temp_c"`
+	TempF        float64   `json:"temp_f"`
+	IsDay        int       `json:"is_day"`
+	Condition    Condition `json:"condition"`
+	WindMph      float64   `json:"wind_mph"`
+	WindKph      float64   `json:"wind_kph"`
+	WindDegree   int       `json:"wind_degree"`
+	WindDir      string    `json:"wind_dir"`
+	PressureMb   float64   `json:"pressure_mb"`
+	PressureIn   float64   `json:"pressure_in"`
+	PrecipMm     float64   `json:"precip_mm"`
+	PrecipIn     float64   `json:"precip_in"`
+	SnowCm       float64   `json:"snow_cm"`
+	Humidity     int       `json:"humidity"`
+	Cloud        int       `json:"cloud"`
+	FeelslikeC   float64   `json:"feelslike_c"`
+	FeelslikeF   float64   `json:"feelslike_f"`
+	WindchillC   float64   `json:"windchill_c"`
+	WindchillF   float64   `json:"windchill_f"`
+	HeatindexC   float64   `json:"heatindex_c"`
+	HeatindexF   float64   `json:"heatindex_f"`
+	DewpointC    float64   `json:"dewpoint_c"`
+	DewpointF    float64   `json:"dewpoint_f"`
+	WillItRain   int       `json:"will_it_rain"`
+	ChanceOfRain float64   `json:"chance_of_rain"`
+	WillItSnow   int       `json:"will_it_snow"`
+	ChanceOfSnow float64   `json:"chance_of_snow"`
+	VisKm        float64   `json:"vis_km"`
+	VisMiles     float64   `json:"vis_miles"`
+	GustMph      float64   `json:"gust_mph"`
+	GustKph      float64   `json:"gust_kph"`
+	UV           float64   `json:"uv"`
+	ShortRad     float64   `json:"short_rad"`
+	DiffRad      float64   `json:"diff_rad"`
+	DNI          float64   `json:"dni"`
+	GTI          float64   `json:"gti"`
+}
+
+// SearchResult es cada coincidencia devuelta por search.json
+type SearchResult struct {
+	ID      int     `json:"id"`
+	Name    string  `json:"name"`
+	Region  string  `json:"region"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+	URL     string  `json:"url"`
 }
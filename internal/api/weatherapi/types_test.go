@@ -0,0 +1,91 @@
+package weatherapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// El fixture de la raíz del repo es una respuesta real de forecast.json;
+// decodificarlo con DisallowUnknownFields garantiza que los tipos cubren
+// todos sus campos.
+func TestWeather_DecodesFixture(t *testing.T) {
+	data, err := os.ReadFile("../../../weather.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var w Weather
+	if err := dec.Decode(&w); err != nil {
+		t.Fatalf("decode fixture: %v", err)
+	}
+
+	if w.Location.TzID != "Europe/Madrid" || w.Location.Region != "Galicia" {
+		t.Errorf("unexpected location: %+v", w.Location)
+	}
+	if w.Current.Condition.Code != 1135 || w.Current.WindDir != "NNW" || w.Current.GustKph != 9.9 {
+		t.Errorf("unexpected current: %+v", w.Current)
+	}
+	if len(w.Forecast.Forecastday) == 0 || len(w.Forecast.Forecastday[0].Hour) != 24 {
+		t.Fatalf("unexpected forecast days: %+v", w.Forecast)
+	}
+	fd := w.Forecast.Forecastday[0]
+	if fd.Astro.MoonPhase != "Waning Crescent" || fd.Astro.MoonIllumination != 44 {
+		t.Errorf("unexpected astro: %+v", fd.Astro)
+	}
+}
+
+func TestToForecast_Fixture(t *testing.T) {
+	data, err := os.ReadFile("../../../weather.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	var w Weather
+	if err := json.Unmarshal(data, &w); err != nil {
+		t.Fatal(err)
+	}
+
+	f := toForecast(&w)
+	if f.Location.Lat != 42.2333 || f.Location.TimeZone != "Europe/Madrid" {
+		t.Errorf("unexpected location: %+v", f.Location)
+	}
+	d := f.Days[0]
+	if d.Date.Format("2006-01-02") != "2025-09-15" || d.MaxWindKph != 20.2 || d.AvgHumidity != 82 {
+		t.Errorf("unexpected day: %+v", d)
+	}
+	if h := d.Hours[0]; h.Condition.Text != "Clear" || h.Condition.Code != 1000 || h.Condition.IsDay {
+		t.Errorf("unexpected first hour condition: %+v", h.Condition)
+	}
+}
+
+func TestToForecast_AirQualityAndAlerts(t *testing.T) {
+	payload := `{
+      "location": {"name": "Vigo", "tz_id": "Europe/Madrid"},
+      "current": {
+        "temp_c": 16,
+        "air_quality": {"co": 210.5, "no2": 4.1, "o3": 60, "so2": 1.2, "pm2_5": 3.4, "pm10": 5.6,
+          "us-epa-index": 1, "gb-defra-index": 2}
+      },
+      "forecast": {"forecastday": []},
+      "alerts": {"alert": [{
+        "headline": "Aviso amarillo por lluvia", "severity": "Moderate", "event": "Rain",
+        "areas": "Rías Baixas", "effective": "2025-09-15T10:00:00+02:00", "expires": "2025-09-15T22:00:00+02:00"
+      }]}
+    }`
+	var w Weather
+	if err := json.Unmarshal([]byte(payload), &w); err != nil {
+		t.Fatal(err)
+	}
+
+	f := toForecast(&w)
+	aq := f.Current.AirQuality
+	if aq == nil || aq.PM25 != 3.4 || aq.USEPAIndex != 1 || aq.GBDefraIndex != 2 {
+		t.Fatalf("unexpected air quality: %+v", aq)
+	}
+	if len(f.Alerts) != 1 || f.Alerts[0].Severity != "Moderate" || f.Alerts[0].Expires.UTC().Hour() != 20 {
+		t.Fatalf("unexpected alerts: %+v", f.Alerts)
+	}
+}
@@ -0,0 +1,87 @@
+// Package cache implementa una caché en disco muy sencilla para las
+// respuestas de los proveedores del tiempo, de forma que invocaciones
+// frecuentes (prompts, barras de estado, tmux...) no gasten cuota de la API.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache guarda respuestas crudas en ficheros dentro de dir. Una entrada es
+// válida mientras su antigüedad no supere ttl; con ttl <= 0 nunca hay
+// aciertos, pero las respuestas nuevas se siguen guardando (modo refresh).
+type Cache struct {
+	dir string
+	ttl time.Duration
+}
+
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl}
+}
+
+// DefaultDir devuelve el directorio de caché del usuario
+// ($XDG_CACHE_HOME/cliweather o ~/.cache/cliweather en Linux).
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "cliweather"), nil
+}
+
+// Key construye una clave estable a partir de las partes de la petición.
+func Key(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get devuelve la entrada asociada a key si existe y no ha caducado.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	if c == nil || c.ttl <= 0 {
+		return nil, false
+	}
+	p := c.path(key)
+	fi, err := os.Stat(p)
+	if err != nil || time.Since(fi.ModTime()) > c.ttl {
+		return nil, false
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put guarda data bajo key. La escritura es atómica (fichero temporal +
+// rename) para que lecturas concurrentes nunca vean un fichero a medias.
+func (c *Cache) Put(key string, data []byte) error {
+	if c == nil {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache_PutGet(t *testing.T) {
+	c := New(t.TempDir(), time.Minute)
+	key := Key("forecast", "Vigo", "1", "es")
+
+	if _, ok := c.Get(key); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+	if err := c.Put(key, []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	got, ok := c.Get(key)
+	if !ok {
+		t.Fatal("expected hit after put")
+	}
+	if string(got) != `{"ok":true}` {
+		t.Fatalf("unexpected data: %s", got)
+	}
+}
+
+func TestCache_Expired(t *testing.T) {
+	dir := t.TempDir()
+	c := New(dir, time.Minute)
+	key := Key("forecast", "Vigo")
+	if err := c.Put(key, []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Envejecemos la entrada más allá del TTL
+	old := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, key+".json"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get(key); ok {
+		t.Fatal("expected miss for expired entry")
+	}
+}
+
+func TestCache_RefreshNeverHits(t *testing.T) {
+	dir := t.TempDir()
+	key := Key("forecast", "Vigo")
+	if err := New(dir, time.Minute).Put(key, []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := New(dir, 0).Get(key); ok {
+		t.Fatal("expected miss with ttl 0")
+	}
+}
+
+func TestKey_Distinct(t *testing.T) {
+	if Key("a", "bc") == Key("ab", "c") {
+		t.Fatal("keys must not collide when parts are shifted")
+	}
+}
@@ -1,40 +1,291 @@
-// internal/config/config.go
+// Package config reúne los ajustes de cliweather. Cada ajuste sale, de más a
+// menos prioridad, de su flag, de su variable de entorno (CLIWEATHER_* o las
+// antiguas WEATHER_*), del fichero de configuración (YAML o TOML) o de su
+// valor por defecto.
 package config
 
 import (
-	"log"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
 type Config struct {
+	File        string // fichero de configuración leído, "" si no hay
+	Location    string // consulta por defecto de --city
 	APIKey      string
+	Provider    string
 	Language    string
+	Units       string // metric, imperial o uk
+	WindUnit    string // opcional: kmh, mph, ms, knots o beaufort
 	Days        int
 	Timeout     time.Duration
 	EnableCache bool
 	CacheTTL    time.Duration
+	Output      string // formato de --output si el comando lo admite
+	Color       string // auto, always o never
+	Emoji       bool
+	Icons       string // emoji, nerd o ascii
+	IconsFile   string // opcional: JSON con iconos propios
+	Places      []Place
 }
 
-func FromEnv() Config {
+// FileEnv es la variable con la ruta del fichero, como --config
+const FileEnv = "CLIWEATHER_CONFIG"
 
-	err := godotenv.Load()
+// fileNames son los nombres que se buscan en el directorio de configuración
+var fileNames = []string{"config.yaml", "config.yml", "config.toml"}
+
+// ErrUnknownKey indica una clave del fichero que no es ningún ajuste
+var ErrUnknownKey = errors.New("unknown setting")
+
+// KeyError es un ajuste con un valor no válido, o una clave desconocida del
+// fichero, junto al sitio de donde salió
+type KeyError struct {
+	Key    string
+	Value  string
+	Source string // ruta del fichero, variable de entorno o --flag
+	Err    error
+}
+
+func (e *KeyError) Error() string {
+	if errors.Is(e.Err, ErrUnknownKey) {
+		return fmt.Sprintf("%s: unknown setting %q", e.Source, e.Key)
+	}
+	return fmt.Sprintf("%s: %s: invalid value %q: %v", e.Source, e.Key, e.Value, e.Err)
+}
+
+func (e *KeyError) Unwrap() error { return e.Err }
+
+// DefaultDir devuelve el directorio de configuración del usuario
+// ($XDG_CONFIG_HOME/cliweather o ~/.config/cliweather en Linux).
+func DefaultDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "cliweather"), nil
+}
+
+// FindFile devuelve el fichero a leer: explicit (--config), CLIWEATHER_CONFIG
+// o el primero de config.yaml, config.yml y config.toml que exista en
+// DefaultDir. Devuelve "" si no se indicó ninguno y no hay ninguno.
+func FindFile(explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	if env := os.Getenv(FileEnv); env != "" {
+		return env
+	}
+	dir, err := DefaultDir()
 	if err != nil {
-		log.Println("Warning: .env file not found or failed to load")
+		return ""
+	}
+	for _, name := range fileNames {
+		if path := filepath.Join(dir, name); fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+func fileExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && !fi.IsDir()
+}
+
+// Source es de dónde sale el valor efectivo de un ajuste
+type Source string
+
+const (
+	FromDefault Source = "default"
+	FromFile    Source = "file"
+	FromEnv     Source = "env"
+	FromFlag    Source = "flag"
+)
+
+// Value es el valor efectivo de un ajuste y su procedencia. Origin concreta
+// Source: el --flag, la variable de entorno o la ruta del fichero.
+type Value struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+	Origin string `json:"origin,omitempty"`
+}
+
+// Load lee la configuración del fichero file (ver FindFile), del entorno y
+// de los flags de fs que se hayan indicado (fs puede ser nil). Si algún
+// ajuste no es válido devuelve todos los errores (*KeyError) y una Config
+// con el valor por defecto en su lugar, para poder seguir informando.
+func Load(file string, fs *pflag.FlagSet) (Config, error) {
+	values, places, path, err := inspect(file, fs)
+	cfg := Config{File: path, Places: places}
+	byKey := map[string]string{}
+	for _, v := range values {
+		byKey[v.Key] = v.Value
+	}
+	fill(&cfg, byKey)
+	return cfg, err
+}
+
+// Inspect devuelve el valor efectivo de cada ajuste, en el orden de Keys, con
+// su procedencia, y la ruta del fichero leído ("" si no hay). Los valores no
+// válidos se sustituyen por el de por defecto y se devuelven como errores.
+func Inspect(file string, fs *pflag.FlagSet) ([]Value, string, error) {
+	values, _, path, err := inspect(file, fs)
+	return values, path, err
+}
+
+// inspect es Inspect devolviendo además las localizaciones guardadas
+func inspect(file string, fs *pflag.FlagSet) ([]Value, []Place, string, error) {
+	// .env es opcional: sus variables no pisan las del entorno
+	_ = godotenv.Load()
+
+	v := viper.New()
+	for _, k := range Keys {
+		def := k.Default
+		_ = v.BindEnv(append([]string{k.Name, k.Env()}, k.Legacy...)...)
+		if fs != nil && k.Flag != "" {
+			if f := fs.Lookup(k.Flag); f != nil {
+				_ = v.BindFlagValue(k.Name, flagValue{f, k.fromFlag})
+				if k.FlagDefault {
+					def = f.DefValue
+				}
+			}
+		}
+		v.SetDefault(k.Name, def)
+	}
+
+	path := FindFile(file)
+	var errs []error
+	if path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			var pathErr *os.PathError
+			if !errors.As(err, &pathErr) {
+				err = fmt.Errorf("%s: %w", path, err)
+			}
+			return defaults(), nil, path, err
+		}
+		errs = append(errs, unknownKeys(v, path)...)
+	}
+	places, placeErrs := readPlaces(v, path)
+	errs = append(errs, placeErrs...)
+
+	// Cada valor se valida como texto, venga de donde venga, y si no vale se
+	// usa el de por defecto
+	values := make([]Value, 0, len(Keys))
+	for _, k := range Keys {
+		src, origin := source(v, k, fs, path)
+		value := Value{Key: k.Name, Value: v.GetString(k.Name), Source: src, Origin: origin}
+		if err := k.Check(value.Value); err != nil {
+			errs = append(errs, &KeyError{Key: k.Name, Value: value.Value, Source: origin, Err: err})
+			value = Value{Key: k.Name, Value: fmt.Sprint(k.Default), Source: FromDefault}
+		}
+		values = append(values, value)
+	}
+	return values, places, path, errors.Join(errs...)
+}
+
+// defaults devuelve los valores por defecto de todos los ajustes
+func defaults() []Value {
+	values := make([]Value, 0, len(Keys))
+	for _, k := range Keys {
+		values = append(values, Value{Key: k.Name, Value: fmt.Sprint(k.Default), Source: FromDefault})
+	}
+	return values
+}
+
+// fill convierte los valores ya validados a los campos de cfg
+func fill(cfg *Config, values map[string]string) {
+	duration := func(key string) time.Duration {
+		d, _ := time.ParseDuration(values[key])
+		return d
 	}
+	boolean := func(key string) bool {
+		b, _ := strconv.ParseBool(values[key])
+		return b
+	}
+	cfg.Location = values["location"]
+	cfg.APIKey = values["api_key"]
+	cfg.Provider = values["provider"]
+	cfg.Language = values["language"]
+	cfg.Units = values["units"]
+	cfg.WindUnit = values["wind_unit"]
+	cfg.Days, _ = strconv.Atoi(values["days"])
+	cfg.Timeout = duration("timeout")
+	cfg.EnableCache = boolean("cache.enabled")
+	cfg.CacheTTL = duration("cache.ttl")
+	cfg.Output = strings.ToLower(values["output"])
+	cfg.Color = strings.ToLower(values["theme.color"])
+	cfg.Emoji = boolean("theme.emoji")
+	cfg.Icons = values["theme.icons"]
+	cfg.IconsFile = values["theme.icons_file"]
+}
+
+// unknownKeys avisa de las claves del fichero que no son ningún ajuste,
+// normalmente erratas que de otro modo se ignorarían sin decir nada
+func unknownKeys(v *viper.Viper, file string) []error {
+	var errs []error
+	for _, key := range v.AllKeys() {
+		if _, ok := Lookup(key); !ok && v.InConfig(key) && !strings.HasPrefix(key, placesKey+".") {
+			errs = append(errs, &KeyError{Key: key, Source: file, Err: ErrUnknownKey})
+		}
+	}
+	slices.SortFunc(errs, func(a, b error) int { return strings.Compare(a.Error(), b.Error()) })
+	return errs
+}
+
+// source dice de dónde sale el valor de k con la misma prioridad que viper:
+// --flag, variable de entorno, fichero o valor por defecto
+func source(v *viper.Viper, k Key, fs *pflag.FlagSet, file string) (Source, string) {
+	if fs != nil && k.Flag != "" {
+		if f := fs.Lookup(k.Flag); f != nil && f.Changed {
+			return FromFlag, "--" + k.Flag
+		}
+	}
+	for _, env := range append([]string{k.Env()}, k.Legacy...) {
+		if os.Getenv(env) != "" {
+			return FromEnv, env
+		}
+	}
+	if v.InConfig(k.Name) {
+		return FromFile, file
+	}
+	return FromDefault, ""
+}
+
+// flagValue adapta un flag de pflag a viper traduciendo su valor si hace
+// falta (--no-color → theme.color: never)
+type flagValue struct {
+	f    *pflag.Flag
+	conv func(string) string
+}
+
+func (fv flagValue) HasChanged() bool  { return fv.f.Changed }
+func (fv flagValue) Name() string      { return fv.f.Name }
+func (fv flagValue) ValueType() string { return "string" }
 
-	apiKey := os.Getenv("WEATHER_API_KEY")
-	if apiKey == "" {
-		// you may decide to return empty and error later if not provided via flag
+func (fv flagValue) ValueString() string {
+	value := fv.f.Value.String()
+	// De un flag repetible (--city en forecast) cuenta el primer valor
+	if sv, ok := fv.f.Value.(pflag.SliceValue); ok {
+		value = ""
+		if s := sv.GetSlice(); len(s) > 0 {
+			value = s[0]
+		}
 	}
-	return Config{
-		APIKey:      apiKey,
-		Language:    "es",
-		Days:        1,
-		Timeout:     10 * time.Second,
-		EnableCache: true,
-		CacheTTL:    10 * time.Minute,
+	if fv.conv != nil {
+		return fv.conv(value)
 	}
+	return value
 }
@@ -0,0 +1,244 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// isolate deja el entorno sin ajustes de cliweather y sin fichero
+func isolate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	// Sin .env en el directorio de trabajo
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, "CLIWEATHER_") || strings.HasPrefix(name, "WEATHER_") {
+			t.Setenv(name, "")
+		}
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, path, data string) string {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func flags() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.StringP("city", "c", "", "")
+	fs.String("units", "", "")
+	fs.IntP("days", "d", 1, "")
+	fs.Bool("no-cache", false, "")
+	fs.Bool("no-color", false, "")
+	return fs
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	isolate(t)
+	cfg, err := Load("", flags())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Config{Location: "Vigo", Provider: "weatherapi", Language: "es", Units: "metric", Days: 1, Timeout: 10 * time.Second,
+		EnableCache: true, CacheTTL: 10 * time.Minute, Output: "text", Color: "auto", Emoji: true}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("defaults = %+v", cfg)
+	}
+}
+
+// Un comando con otro valor por defecto para -d (tui) lo impone sobre el de
+// Keys, pero no sobre el entorno
+func TestLoad_FlagDefault(t *testing.T) {
+	isolate(t)
+	fs := pflag.NewFlagSet("tui", pflag.ContinueOnError)
+	fs.IntP("days", "d", 3, "")
+
+	cfg, err := Load("", fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Days != 3 {
+		t.Errorf("days = %d, want the flag default 3", cfg.Days)
+	}
+	values, _, _ := Inspect("", fs)
+	for _, v := range values {
+		if v.Key == "days" && (v.Value != "3" || v.Source != FromDefault) {
+			t.Errorf("days = %+v, want 3 from default", v)
+		}
+	}
+
+	t.Setenv("CLIWEATHER_DAYS", "2")
+	if cfg, _ := Load("", fs); cfg.Days != 2 {
+		t.Errorf("days = %d, want 2 from the environment", cfg.Days)
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	dir := isolate(t)
+	path := writeFile(t, filepath.Join(dir, "cliweather", "config.yaml"), `
+location: Lisbon
+units: imperial
+days: 3
+language: pt
+cache:
+  ttl: 1h
+theme:
+  color: always
+  icons: nerd
+`)
+	t.Setenv("CLIWEATHER_UNITS", "uk")
+	t.Setenv("CLIWEATHER_DAYS", "2")
+	t.Setenv("WEATHER_LANG", "gl")
+
+	fs := flags()
+	if err := fs.Parse([]string{"-d", "5", "--no-cache", "--no-color"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load("", fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.File != path {
+		t.Errorf("file = %q, want %q", cfg.File, path)
+	}
+	// fichero < entorno (también las WEATHER_* antiguas) < flags
+	if cfg.Location != "Lisbon" || cfg.Units != "uk" || cfg.Language != "gl" || cfg.Days != 5 {
+		t.Errorf("precedence: %+v", cfg)
+	}
+	if cfg.EnableCache || cfg.CacheTTL != time.Hour || cfg.Color != "never" || cfg.Icons != "nerd" {
+		t.Errorf("sections and --no-* flags: %+v", cfg)
+	}
+
+	// CLIWEATHER_* gana a la variable antigua
+	t.Setenv("CLIWEATHER_LANGUAGE", "en")
+	if cfg, _ := Load("", nil); cfg.Language != "en" {
+		t.Errorf("language = %q, want en", cfg.Language)
+	}
+}
+
+func TestLoad_RepeatedCity(t *testing.T) {
+	isolate(t)
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.StringArrayP("city", "c", nil, "")
+	if err := fs.Parse([]string{"-c", "42.23,-8.72", "-c", "Oslo"}); err != nil {
+		t.Fatal(err)
+	}
+	// De un --city repetible cuenta el primero, con sus comas
+	if cfg, err := Load("", fs); err != nil || cfg.Location != "42.23,-8.72" {
+		t.Errorf("location = %q, %v", cfg.Location, err)
+	}
+}
+
+func TestLoad_TOML(t *testing.T) {
+	dir := isolate(t)
+	path := writeFile(t, filepath.Join(dir, "other.toml"), "location = \"Madrid\"\ntimeout = \"30s\"\n\n[theme]\nemoji = false\n")
+	cfg, err := Load(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Location != "Madrid" || cfg.Timeout != 30*time.Second || cfg.Emoji {
+		t.Errorf("toml: %+v", cfg)
+	}
+
+	// CLIWEATHER_CONFIG equivale a --config
+	t.Setenv(FileEnv, path)
+	if cfg, _ := Load("", nil); cfg.Location != "Madrid" {
+		t.Errorf("CLIWEATHER_CONFIG not read: %+v", cfg)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := isolate(t)
+	path := writeFile(t, filepath.Join(dir, "cliweather", "config.yml"), "units: metrik\ntimeout: 0s\nprovider: weatherapi,darksky\ntheme:\n  colour: never\n")
+	t.Setenv("CLIWEATHER_DAYS", "many")
+
+	cfg, err := Load("", nil)
+	var unknown, units, days *KeyError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		ke := e.(*KeyError)
+		switch ke.Key {
+		case "theme.colour":
+			unknown = ke
+		case "units":
+			units = ke
+		case "days":
+			days = ke
+		}
+	}
+	if unknown == nil || !errors.Is(unknown, ErrUnknownKey) || unknown.Source != path {
+		t.Errorf("unknown key not reported: %v", err)
+	}
+	if units == nil || units.Value != "metrik" || units.Source != path {
+		t.Errorf("units error: %v", err)
+	}
+	if days == nil || days.Source != "CLIWEATHER_DAYS" {
+		t.Errorf("days error must name the variable: %v", err)
+	}
+	for _, key := range []string{"timeout", "provider"} {
+		if !strings.Contains(err.Error(), key+": invalid value") {
+			t.Errorf("no error for %s: %v", key, err)
+		}
+	}
+	// Los ajustes no válidos quedan con su valor por defecto
+	if cfg.Units != "metric" || cfg.Days != 1 || cfg.Timeout != 10*time.Second {
+		t.Errorf("invalid values must fall back to defaults: %+v", cfg)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.yaml"), nil); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing --config file: %v", err)
+	}
+}
+
+func TestInspect_Sources(t *testing.T) {
+	dir := isolate(t)
+	path := writeFile(t, filepath.Join(dir, "cliweather", "config.yaml"), "units: imperial\ndays: 2\n")
+	t.Setenv("WEATHER_PROVIDER", "openmeteo")
+	fs := flags()
+	if err := fs.Parse([]string{"--no-color"}); err != nil {
+		t.Fatal(err)
+	}
+
+	values, file, err := Inspect("", fs)
+	if err != nil || file != path {
+		t.Fatalf("file = %q, err = %v", file, err)
+	}
+	got := map[string]Value{}
+	for _, v := range values {
+		got[v.Key] = v
+	}
+	want := map[string]Value{
+		"units":       {Key: "units", Value: "imperial", Source: FromFile, Origin: path},
+		"provider":    {Key: "provider", Value: "openmeteo", Source: FromEnv, Origin: "WEATHER_PROVIDER"},
+		"theme.color": {Key: "theme.color", Value: "never", Source: FromFlag, Origin: "--no-color"},
+		"location":    {Key: "location", Value: "Vigo", Source: FromDefault},
+	}
+	for key, w := range want {
+		if got[key] != w {
+			t.Errorf("%s = %+v, want %+v", key, got[key], w)
+		}
+	}
+	if len(values) != len(Keys) || values[0].Key != Keys[0].Name {
+		t.Error("values must follow the order of Keys")
+	}
+}
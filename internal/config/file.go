@@ -0,0 +1,272 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// Path devuelve el fichero que leería Load (ver FindFile) o, si no hay
+// ninguno, dónde se crearía: config.yaml en DefaultDir
+func Path(explicit string) (string, error) {
+	if path := FindFile(explicit); path != "" {
+		return path, nil
+	}
+	dir, err := DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileNames[0]), nil
+}
+
+// isTOML decide el formato del fichero por su extensión; todo lo demás se
+// trata como YAML
+func isTOML(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".toml")
+}
+
+// Set valida value y lo guarda como valor de key en el fichero path,
+// creándolo si no existe. En YAML se conservan los comentarios; en TOML el
+// fichero se reescribe sin ellos.
+func Set(path, key, value string) error {
+	k, ok := Lookup(key)
+	if !ok {
+		return &KeyError{Key: key, Source: path, Err: ErrUnknownKey}
+	}
+	if err := k.Check(value); err != nil {
+		return &KeyError{Key: k.Name, Value: value, Source: path, Err: err}
+	}
+	return setPath(path, strings.Split(k.Name, "."), k.Parse(value))
+}
+
+// Unset quita key del fichero path, de modo que vuelve a valer lo que diga
+// el entorno o el valor por defecto. Quitar una clave que no está no es un
+// error.
+func Unset(path, key string) error {
+	k, ok := Lookup(key)
+	if !ok {
+		return &KeyError{Key: key, Source: path, Err: ErrUnknownKey}
+	}
+	return setPath(path, strings.Split(k.Name, "."), nil)
+}
+
+// setPath fija (o quita, con value nil) la clave keys (sección y nombre, o
+// solo nombre) en el fichero path
+func setPath(path string, keys []string, value any) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) && value == nil {
+		return nil
+	}
+	return edit(path, func(data []byte) ([]byte, error) {
+		if isTOML(path) {
+			return editTOML(data, keys, value)
+		}
+		return editYAML(data, keys, value)
+	})
+}
+
+// edit aplica change al contenido de path (vacío si no existe) y lo guarda
+func edit(path string, change func([]byte) ([]byte, error)) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	out, err := change(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// El fichero puede llevar la clave de la API: solo para el usuario
+	return os.WriteFile(path, out, 0o600)
+}
+
+// editYAML fija (o quita, con value nil) keys en el árbol del documento, que
+// conserva los comentarios al volver a escribirlo
+func editYAML(data []byte, keys []string, value any) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	// Un fichero vacío o solo con comentarios no tiene árbol: se añade la
+	// clave al final del texto
+	if doc.Kind == 0 {
+		if value == nil {
+			return data, nil
+		}
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+		setNode(doc.Content[0], keys, value)
+		out, err := encodeYAML(&doc)
+		if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+			data = append(data, '\n')
+		}
+		return append(data, out...), err
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("the document is not a mapping of settings")
+	}
+	if value == nil {
+		unsetNode(root, keys)
+	} else {
+		setNode(root, keys, value)
+	}
+	return encodeYAML(&doc)
+}
+
+func encodeYAML(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	err := enc.Close()
+	return buf.Bytes(), err
+}
+
+// setNode fija path dentro del mapa m, creando las secciones que falten y
+// conservando los comentarios del valor anterior. Las claves no distinguen
+// mayúsculas, como al leerlas.
+func setNode(m *yaml.Node, path []string, value any) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if !strings.EqualFold(m.Content[i].Value, path[0]) {
+			continue
+		}
+		old := m.Content[i+1]
+		if len(path) > 1 {
+			if old.Kind != yaml.MappingNode {
+				*old = yaml.Node{Kind: yaml.MappingNode, HeadComment: old.HeadComment, LineComment: old.LineComment}
+			}
+			setNode(old, path[1:], value)
+			return
+		}
+		var n yaml.Node
+		_ = n.Encode(value)
+		n.HeadComment, n.LineComment, n.FootComment = old.HeadComment, old.LineComment, old.FootComment
+		if n.Tag == "!!str" && old.Kind == yaml.ScalarNode {
+			n.Style = old.Style // mantiene las comillas que hubiera
+		}
+		m.Content[i+1] = &n
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}
+	if len(path) > 1 {
+		child := &yaml.Node{Kind: yaml.MappingNode}
+		setNode(child, path[1:], value)
+		m.Content = append(m.Content, key, child)
+		return
+	}
+	var n yaml.Node
+	_ = n.Encode(value)
+	m.Content = append(m.Content, key, &n)
+}
+
+// unsetNode quita path del mapa m y las secciones que queden vacías
+func unsetNode(m *yaml.Node, path []string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if !strings.EqualFold(m.Content[i].Value, path[0]) {
+			continue
+		}
+		if len(path) > 1 {
+			child := m.Content[i+1]
+			if child.Kind != yaml.MappingNode {
+				return
+			}
+			unsetNode(child, path[1:])
+			if len(child.Content) > 0 {
+				return
+			}
+		}
+		m.Content = append(m.Content[:i], m.Content[i+2:]...)
+		return
+	}
+}
+
+// editTOML fija (o quita, con value nil) keys reescribiendo el documento
+func editTOML(data []byte, keys []string, value any) ([]byte, error) {
+	doc := map[string]any{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	section, name := "", keys[0]
+	if len(keys) > 1 {
+		section, name = keys[0], keys[1]
+	}
+	m := doc
+	if section != "" {
+		sub, ok := doc[section].(map[string]any)
+		if !ok {
+			if value == nil {
+				return data, nil
+			}
+			sub = map[string]any{}
+			doc[section] = sub
+		}
+		m = sub
+	}
+	if value == nil {
+		delete(m, name)
+		if section != "" && len(m) == 0 {
+			delete(doc, section)
+		}
+	} else {
+		m[name] = value
+	}
+	return toml.Marshal(doc)
+}
+
+// Template devuelve un fichero de configuración con todos los ajustes a su
+// valor por defecto, cada uno con su comentario, en TOML si path acaba en
+// .toml y si no en YAML. No lleva líneas en blanco entre ajustes porque Set
+// no las conserva al reescribir el YAML.
+func Template(path string) []byte {
+	toTOML := isTOML(path)
+	var b bytes.Buffer
+	b.WriteString("# cliweather configuration.\n")
+	b.WriteString("# Precedence: flags > environment (CLIWEATHER_*) > this file > defaults.\n")
+	b.WriteString("# `cliweather config show` lists the values in effect and where they come from.\n\n")
+
+	// Primero los ajustes del nivel superior (TOML lo exige) y luego cada
+	// sección en el orden de Keys
+	var sections []string
+	bySection := map[string][]Key{}
+	for _, k := range Keys {
+		section, _ := k.Section()
+		if _, ok := bySection[section]; !ok && section != "" {
+			sections = append(sections, section)
+		}
+		bySection[section] = append(bySection[section], k)
+	}
+	for _, section := range append([]string{""}, sections...) {
+		indent := ""
+		if section != "" {
+			if toTOML {
+				fmt.Fprintf(&b, "\n[%s]\n", section)
+			} else {
+				fmt.Fprintf(&b, "%s:\n", section)
+				indent = "  "
+			}
+		}
+		for _, k := range bySection[section] {
+			_, name := k.Section()
+			value := fmt.Sprint(k.Default)
+			if _, ok := k.Default.(string); ok {
+				value = strconv.Quote(value)
+			}
+			sep := ":"
+			if toTOML {
+				sep = " ="
+			}
+			fmt.Fprintf(&b, "%s# %s\n%s%s%s %s\n", indent, k.Doc, indent, name, sep, value)
+		}
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplate_LoadsAsDefaults(t *testing.T) {
+	dir := isolate(t)
+	want, err := Load("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"config.yaml", "config.toml"} {
+		path := writeFile(t, filepath.Join(dir, name), string(Template(name)))
+		cfg, err := Load(path, nil)
+		if err != nil {
+			t.Fatalf("%s: %v\n%s", name, err, Template(name))
+		}
+		want.File = path
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("%s: %+v, want %+v", name, cfg, want)
+		}
+		if !strings.Contains(string(Template(name)), "# Unit system: metric, imperial or uk\n") {
+			t.Errorf("%s: settings must be documented:\n%s", name, Template(name))
+		}
+	}
+}
+
+func TestSet_YAML(t *testing.T) {
+	dir := isolate(t)
+	path := filepath.Join(dir, "cliweather", "config.yaml")
+
+	// Sin fichero se crea
+	if err := Set(path, "theme.color", "never"); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, "# mine\nunits: metric # keep\ntheme:\n  color: never\n")
+	for _, kv := range [][2]string{{"units", "imperial"}, {"days", "3"}, {"cache.enabled", "false"}} {
+		if err := Set(path, kv[0], kv[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	want := "# mine\nunits: imperial # keep\ntheme:\n  color: never\ndays: 3\ncache:\n  enabled: false\n"
+	if string(data) != want {
+		t.Errorf("after set:\n%s\nwant\n%s", data, want)
+	}
+
+	if err := Unset(path, "theme.color"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Unset(path, "location"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Units != "imperial" || cfg.Days != 3 || cfg.EnableCache || cfg.Color != "auto" {
+		t.Errorf("after unset: %+v", cfg)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "theme") {
+		t.Errorf("empty sections must be removed:\n%s", data)
+	}
+}
+
+func TestSet_TOML(t *testing.T) {
+	dir := isolate(t)
+	path := writeFile(t, filepath.Join(dir, "config.toml"), "location = \"Vigo\"\n")
+	if err := Set(path, "cache.ttl", "1h"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Unset(path, "location"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.CacheTTL != time.Hour || cfg.Location != "Vigo" {
+		t.Errorf("toml: %+v", cfg)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "location") {
+		t.Errorf("location not removed:\n%s", data)
+	}
+}
+
+func TestSet_Invalid(t *testing.T) {
+	path := filepath.Join(isolate(t), "config.yaml")
+	var ke *KeyError
+	if err := Set(path, "days", "zero"); !errors.As(err, &ke) || ke.Key != "days" {
+		t.Errorf("invalid value: %v", err)
+	}
+	if err := Set(path, "colour", "never"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("unknown key: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("a rejected value must not create the file")
+	}
+}
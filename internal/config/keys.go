@@ -0,0 +1,238 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"mruiz/cliWeather/internal/icons"
+	"mruiz/cliWeather/internal/units"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Key describe un ajuste: su nombre en el fichero (con puntos para las
+// secciones), el flag y las variables de entorno antiguas que lo fijan, su
+// valor por defecto y cómo se valida. Doc es el comentario de la plantilla.
+type Key struct {
+	Name    string
+	Doc     string
+	Flag    string   // flag que lo sobrescribe, "" si no hay
+	Legacy  []string // variables WEATHER_* aceptadas además de CLIWEATHER_*
+	Default any
+
+	// FlagDefault hace que el valor por defecto del flag, si el comando lo
+	// registra, sustituya a Default: así tui pide 3 días y forecast 1
+	FlagDefault bool
+
+	fromFlag func(string) string // traduce el valor del flag, nil si es igual
+	check    func(string) error
+}
+
+// Env devuelve la variable de entorno del ajuste: CLIWEATHER_ y el nombre en
+// mayúsculas con guiones bajos (cache.ttl → CLIWEATHER_CACHE_TTL)
+func (k Key) Env() string {
+	return "CLIWEATHER_" + strings.ToUpper(strings.ReplaceAll(k.Name, ".", "_"))
+}
+
+// Outputs son todos los formatos de --output; cada comando admite una parte
+var Outputs = []string{"text", "json", "csv", "tsv", "ndjson", "statusline", "waybar", "i3blocks", "polybar"}
+
+// Providers son los nombres que admite provider, ordenados. Se repiten aquí
+// para que config no dependa de los clientes de las APIs; un test de
+// provider comprueba que coinciden con los registrados.
+var Providers = []string{"metno", "openmeteo", "weatherapi"}
+
+// Colors son los modos de theme.color
+var Colors = []string{"auto", "always", "never"}
+
+// Keys son los ajustes admitidos, en el orden en que se documentan
+var Keys = []Key{
+	{Name: "location", Doc: "Place name or lat,lon used when --city is not given", Flag: "city", Default: "Vigo", check: notEmpty},
+	{Name: "units", Doc: "Unit system: metric, imperial or uk", Flag: "units", Legacy: []string{"WEATHER_UNITS"}, Default: "metric", check: checkUnits},
+	{Name: "wind_unit", Doc: "Wind unit overriding the unit system: kmh, mph, ms, knots or beaufort", Flag: "wind-unit", Legacy: []string{"WEATHER_WIND_UNIT"}, Default: "", check: optional(checkWind)},
+	{Name: "language", Doc: "Language of labels, dates and conditions: es, en, fr, gl or pt", Flag: "lang", Legacy: []string{"WEATHER_LANG"}, Default: "es", check: checkLanguage},
+	{Name: "provider", Doc: "Weather provider or ordered fallback list, e.g. weatherapi,openmeteo", Flag: "provider", Legacy: []string{"WEATHER_PROVIDER"}, Default: "weatherapi", check: checkProvider},
+	{Name: "api_key", Doc: "WeatherAPI key", Flag: "apikey", Legacy: []string{"WEATHER_API_KEY"}, Default: ""},
+	{Name: "days", Doc: "Forecast days", Flag: "days", Default: 1, FlagDefault: true, check: checkDays},
+	{Name: "timeout", Doc: "Time limit for each request to a provider", Default: "10s", check: positiveDuration},
+	{Name: "cache.enabled", Doc: "Reuse recent provider responses from the disk cache", Flag: "no-cache", fromFlag: negate, Default: true, check: checkBool},
+	{Name: "cache.ttl", Doc: "How long cached responses are reused", Default: "10m", check: checkDuration},
+	{Name: "output", Doc: "Output format for the commands that support it: text, json, csv, tsv, ndjson, statusline, waybar, i3blocks or polybar", Default: "text", check: oneOf(Outputs)},
+	{Name: "theme.color", Doc: "ANSI colors: auto (only on a terminal and without NO_COLOR), always or never", Flag: "no-color", fromFlag: noColor, Default: "auto", check: oneOf(Colors)},
+	{Name: "theme.emoji", Doc: "Show emoji and icons", Flag: "no-emoji", fromFlag: negate, Default: true, check: checkBool},
+	{Name: "theme.icons", Doc: "Condition icon set: emoji, nerd or ascii", Flag: "icons", Legacy: []string{"WEATHER_ICONS"}, Default: "", check: optional(checkIcons)},
+	{Name: "theme.icons_file", Doc: "JSON file with custom condition icons", Flag: "icons-file", Legacy: []string{"WEATHER_ICONS_FILE"}, Default: ""},
+}
+
+// Lookup devuelve el ajuste con ese nombre
+func Lookup(name string) (Key, bool) {
+	for _, k := range Keys {
+		if k.Name == strings.ToLower(name) {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// Section devuelve la sección del ajuste ("" en el nivel superior) y su
+// nombre dentro de ella
+func (k Key) Section() (section, name string) {
+	if i := strings.LastIndex(k.Name, "."); i >= 0 {
+		return k.Name[:i], k.Name[i+1:]
+	}
+	return "", k.Name
+}
+
+// Parse convierte value, ya validado, al tipo del valor por defecto, para
+// escribirlo en el fichero como número o booleano y no como texto
+func (k Key) Parse(value string) any {
+	switch k.Default.(type) {
+	case int:
+		n, _ := strconv.Atoi(value)
+		return n
+	case bool:
+		b, _ := strconv.ParseBool(value)
+		return b
+	default:
+		return value
+	}
+}
+
+// Names devuelve los nombres de todos los ajustes
+func Names() []string {
+	names := make([]string, len(Keys))
+	for i, k := range Keys {
+		names[i] = k.Name
+	}
+	return names
+}
+
+// Check valida value como valor del ajuste
+func (k Key) Check(value string) error {
+	if k.check == nil {
+		return nil
+	}
+	return k.check(value)
+}
+
+// negate traduce los flags --no-* a su ajuste en positivo
+func negate(s string) string {
+	b, _ := strconv.ParseBool(s)
+	return strconv.FormatBool(!b)
+}
+
+// noColor traduce --no-color a theme.color
+func noColor(s string) string {
+	if b, _ := strconv.ParseBool(s); b {
+		return "never"
+	}
+	return "auto"
+}
+
+func notEmpty(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return errors.New("must not be empty")
+	}
+	return nil
+}
+
+// optional acepta el valor vacío y valida con check el resto
+func optional(check func(string) error) func(string) error {
+	return func(s string) error {
+		if s == "" {
+			return nil
+		}
+		return check(s)
+	}
+}
+
+func oneOf(values []string) func(string) error {
+	return func(s string) error {
+		for _, v := range values {
+			if strings.EqualFold(s, v) {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected one of %s", strings.Join(values, ", "))
+	}
+}
+
+func checkUnits(s string) error {
+	_, err := units.Parse(s)
+	return err
+}
+
+func checkWind(s string) error {
+	_, err := units.ParseWind(s)
+	return err
+}
+
+func checkIcons(s string) error {
+	_, err := icons.Get(s)
+	return err
+}
+
+// checkLanguage acepta etiquetas BCP 47 y el formato de LANG (es_ES.UTF-8)
+func checkLanguage(s string) error {
+	tag, _, _ := strings.Cut(s, ".")
+	if _, err := language.Parse(strings.ReplaceAll(tag, "_", "-")); err != nil {
+		return fmt.Errorf("not a language code")
+	}
+	return nil
+}
+
+// checkProvider valida una lista de proveedores separada por comas
+func checkProvider(s string) error {
+	names := Providers
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if err := oneOf(names)(name); err != nil {
+			return fmt.Errorf("unknown provider %q (available: %s)", name, strings.Join(names, ", "))
+		}
+	}
+	return notEmpty(strings.Trim(s, ", "))
+}
+
+func checkDays(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return errors.New("not a whole number")
+	}
+	if n < 1 || n > 14 {
+		return errors.New("must be between 1 and 14")
+	}
+	return nil
+}
+
+func checkBool(s string) error {
+	if _, err := strconv.ParseBool(s); err != nil {
+		return errors.New("expected true or false")
+	}
+	return nil
+}
+
+func checkDuration(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return errors.New("not a duration such as 30s or 10m")
+	}
+	if d < 0 {
+		return errors.New("must not be negative")
+	}
+	return nil
+}
+
+func positiveDuration(s string) error {
+	if err := checkDuration(s); err != nil {
+		return err
+	}
+	if d, _ := time.ParseDuration(s); d == 0 {
+		return errors.New("must be greater than zero")
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// placesKey es la sección del fichero con las localizaciones guardadas:
+//
+//	locations:
+//	  casa: 42.23,-8.72
+//	  oficina: Santiago de Compostela
+const placesKey = "locations"
+
+// ErrUnknownPlace indica un alias que no está guardado
+var ErrUnknownPlace = errors.New("no saved location with that name")
+
+// Place es una localización guardada: un alias para una consulta (nombre o
+// lat,lon) que vale en cualquier sitio donde se acepte --city
+type Place struct {
+	Alias string `json:"alias"`
+	Query string `json:"query"`
+}
+
+// CheckAlias valida un alias: letras, números, - y _. Los alias no
+// distinguen mayúsculas y se guardan en minúsculas.
+func CheckAlias(alias string) error {
+	if alias == "" {
+		return errors.New("must not be empty")
+	}
+	for _, r := range alias {
+		if r != '-' && r != '_' && !('a' <= r && r <= 'z') && !('0' <= r && r <= '9') && !('A' <= r && r <= 'Z') {
+			return fmt.Errorf("%q may only contain letters, digits, - and _", alias)
+		}
+	}
+	return nil
+}
+
+// readPlaces lee y valida la sección de localizaciones del fichero,
+// ordenadas por alias
+func readPlaces(v *viper.Viper, file string) ([]Place, []error) {
+	raw := v.Get(placesKey)
+	if raw == nil {
+		return nil, nil
+	}
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, []error{&KeyError{Key: placesKey, Value: fmt.Sprint(raw), Source: file, Err: errors.New("expected a map of name: place")}}
+	}
+
+	var places []Place
+	var errs []error
+	for alias, q := range m {
+		query := strings.TrimSpace(fmt.Sprint(q))
+		key := placesKey + "." + alias
+		if err := CheckAlias(alias); err != nil {
+			errs = append(errs, &KeyError{Key: key, Value: query, Source: file, Err: err})
+			continue
+		}
+		if _, isMap := q.(map[string]any); isMap || query == "" {
+			errs = append(errs, &KeyError{Key: key, Value: query, Source: file, Err: errors.New("expected a place name or lat,lon")})
+			continue
+		}
+		places = append(places, Place{Alias: strings.ToLower(alias), Query: query})
+	}
+	slices.SortFunc(places, func(a, b Place) int { return strings.Compare(a.Alias, b.Alias) })
+	return places, errs
+}
+
+// Place devuelve la localización guardada con ese alias
+func (c Config) Place(alias string) (Place, bool) {
+	for _, p := range c.Places {
+		if strings.EqualFold(p.Alias, alias) {
+			return p, true
+		}
+	}
+	return Place{}, false
+}
+
+// Resolve traduce un alias guardado a su consulta; cualquier otro texto se
+// devuelve tal cual
+func (c Config) Resolve(query string) string {
+	if p, ok := c.Place(query); ok {
+		return p.Query
+	}
+	return query
+}
+
+// AddPlace guarda query con el alias dado en el fichero path, sustituyendo
+// la que tuviera ese alias
+func AddPlace(path, alias, query string) error {
+	if err := CheckAlias(alias); err != nil {
+		return &KeyError{Key: placesKey + "." + alias, Value: query, Source: path, Err: err}
+	}
+	if strings.TrimSpace(query) == "" {
+		return &KeyError{Key: placesKey + "." + alias, Source: path, Err: errors.New("expected a place name or lat,lon")}
+	}
+	return setPath(path, []string{placesKey, strings.ToLower(alias)}, strings.TrimSpace(query))
+}
+
+// RemovePlace quita el alias del fichero path. Si era la localización por
+// defecto, quita también location.
+func RemovePlace(path string, cfg Config, alias string) error {
+	p, ok := cfg.Place(alias)
+	if !ok {
+		return fmt.Errorf("%q: %w", alias, ErrUnknownPlace)
+	}
+	if err := setPath(path, []string{placesKey, p.Alias}, nil); err != nil {
+		return err
+	}
+	if strings.EqualFold(cfg.Location, p.Alias) {
+		return Unset(path, "location")
+	}
+	return nil
+}
+
+// RenamePlace cambia el alias from por to en el fichero path, y en location
+// si era la localización por defecto
+func RenamePlace(path string, cfg Config, from, to string) error {
+	p, ok := cfg.Place(from)
+	if !ok {
+		return fmt.Errorf("%q: %w", from, ErrUnknownPlace)
+	}
+	if err := CheckAlias(to); err != nil {
+		return &KeyError{Key: placesKey + "." + to, Value: p.Query, Source: path, Err: err}
+	}
+	if _, taken := cfg.Place(to); taken && !strings.EqualFold(from, to) {
+		return &KeyError{Key: placesKey + "." + to, Value: p.Query, Source: path, Err: errors.New("name already in use")}
+	}
+	if err := setPath(path, []string{placesKey, p.Alias}, nil); err != nil {
+		return err
+	}
+	if err := AddPlace(path, to, p.Query); err != nil {
+		return err
+	}
+	if strings.EqualFold(cfg.Location, p.Alias) {
+		return Set(path, "location", strings.ToLower(to))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad_Places(t *testing.T) {
+	dir := isolate(t)
+	path := writeFile(t, filepath.Join(dir, "config.yaml"), `
+location: casa
+locations:
+  Oficina: Santiago de Compostela
+  casa: "42.23,-8.72"
+  "mal nombre": Vigo
+`)
+	cfg, err := Load(path, nil)
+	want := []Place{{"casa", "42.23,-8.72"}, {"oficina", "Santiago de Compostela"}}
+	if !reflect.DeepEqual(cfg.Places, want) {
+		t.Errorf("places = %+v", cfg.Places)
+	}
+	if err == nil || !strings.Contains(err.Error(), `locations.mal nombre`) {
+		t.Errorf("invalid alias not reported: %v", err)
+	}
+	if got := cfg.Resolve(cfg.Location); got != "42.23,-8.72" {
+		t.Errorf("default location resolves to %q", got)
+	}
+	if got := cfg.Resolve("OFICINA"); got != "Santiago de Compostela" {
+		t.Errorf("aliases must ignore case: %q", got)
+	}
+	if got := cfg.Resolve("Lisbon"); got != "Lisbon" {
+		t.Errorf("other queries must pass through: %q", got)
+	}
+}
+
+func TestPlaces_Edit(t *testing.T) {
+	path := filepath.Join(isolate(t), "config.yaml")
+	load := func() Config {
+		t.Helper()
+		cfg, err := Load(path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cfg
+	}
+
+	for _, p := range []Place{{"casa", "42.23,-8.72"}, {"Oficina", "Santiago de Compostela"}} {
+		if err := AddPlace(path, p.Alias, p.Query); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Set(path, "location", "casa"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddPlace(path, "a.b", "Vigo"); err == nil {
+		t.Error("aliases with dots must be rejected")
+	}
+
+	// Renombrar la localización por defecto la sigue
+	if err := RenamePlace(path, load(), "casa", "home"); err != nil {
+		t.Fatal(err)
+	}
+	cfg := load()
+	if cfg.Location != "home" || cfg.Resolve("home") != "42.23,-8.72" {
+		t.Errorf("after rename: %+v", cfg)
+	}
+	if err := RenamePlace(path, cfg, "home", "oficina"); err == nil {
+		t.Error("renaming onto a saved name must fail")
+	}
+
+	// Quitarla devuelve location a su valor por defecto
+	if err := RemovePlace(path, cfg, "home"); err != nil {
+		t.Fatal(err)
+	}
+	cfg = load()
+	if cfg.Location != "Vigo" || len(cfg.Places) != 1 {
+		t.Errorf("after remove: %+v", cfg)
+	}
+	if err := RemovePlace(path, cfg, "home"); !errors.Is(err, ErrUnknownPlace) {
+		t.Errorf("removing twice: %v", err)
+	}
+}
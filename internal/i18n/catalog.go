@@ -0,0 +1,488 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message/catalog"
+)
+
+// translation es la traducción de una clave a cada idioma con catálogo.
+// El inglés no aparece: las propias claves están en inglés.
+type translation struct {
+	es, fr, gl, pt string
+}
+
+// messages es el catálogo de textos de la interfaz, indexado por el texto
+// en inglés. Los verbos de formato deben coincidir en todas las traducciones.
+var messages = map[string]translation{
+	// ===== render: previsión =====
+	"Good day!":              {"¡Buen día!", "Bonjour !", "Bo día!", "Bom dia!"},
+	"Date:":                  {"Fecha:", "Date:", "Data:", "Data:"},
+	"Source:":                {"Fuente:", "Source:", "Fonte:", "Fonte:"},
+	"No forecast available.": {"No hay previsión disponible.", "Aucune prévision disponible.", "Non hai predición dispoñible.", "Nenhuma previsão disponível."},
+	"Invalid day index %d (available: 0..%d)": {"Índice de día %d no válido (disponibles: 0..%d)", "Indice de jour %d invalide (disponibles : 0..%d)", "Índice de día %d non válido (dispoñibles: 0..%d)", "Índice de dia %d inválido (disponíveis: 0..%d)"},
+	"%s (day %d/%d)": {"%s (día %d/%d)", "%s (jour %d/%d)", "%s (día %d/%d)", "%s (dia %d/%d)"},
+	"max:":           {"max:", "max:", "máx:", "máx:"},
+	"avg:":           {"media:", "moy:", "media:", "média:"},
+	"min:":           {"min:", "min:", "mín:", "mín:"},
+	"max wind:":      {"viento max:", "vent max:", "vento máx:", "vento máx:"},
+	"humidity:":      {"humedad:", "humidité:", "humidade:", "humidade:"},
+	"rain:":          {"lluvia:", "pluie:", "choiva:", "chuva:"},
+	"snow:":          {"nieve:", "neige:", "neve:", "neve:"},
+	"sunrise:":       {"amanecer:", "lever:", "amencer:", "nascer do sol:"},
+	"sunset:":        {"atardecer:", "coucher:", "solpor:", "pôr do sol:"},
+
+	// ===== render: tiempo actual =====
+	"(feels like %s)": {"(sensación %s)", "(ressenti %s)", "(sensación %s)", "(sensação %s)"},
+	"wind:":           {"viento:", "vent:", "vento:", "vento:"},
+	"(gusts %s)":      {"(rachas %s)", "(rafales %s)", "(refachos %s)", "(rajadas %s)"},
+	"pressure:":       {"presión:", "pression:", "presión:", "pressão:"},
+	"visibility:":     {"visibilidad:", "visibilité:", "visibilidade:", "visibilidade:"},
+
+	// ===== render: resumen de histórico =====
+	"Summary (%d days)": {"Resumen (%d días)", "Résumé (%d jours)", "Resumo (%d días)", "Resumo (%d dias)"},
+	"total rain:":       {"lluvia total:", "pluie totale:", "choiva total:", "chuva total:"},
+	"rainy days:":       {"días de lluvia:", "jours de pluie:", "días de choiva:", "dias de chuva:"},
+	"total snow:":       {"nieve total:", "neige totale:", "neve total:", "neve total:"},
+	"wettest day:":      {"día más lluvioso:", "jour le plus pluvieux:", "día máis chuvioso:", "dia mais chuvoso:"},
+
+	// ===== render: tabla horaria =====
+	"Time":      {"Hora", "Heure", "Hora", "Hora"},
+	"Temp":      {"Temp", "Temp", "Temp", "Temp"},
+	"Feels":     {"Sensación", "Ressenti", "Sensación", "Sensação"},
+	"Rain":      {"Lluvia", "Pluie", "Choiva", "Chuva"},
+	"Precip":    {"Precip.", "Précip.", "Precip.", "Precip."},
+	"Wind":      {"Viento", "Vent", "Vento", "Vento"},
+	"Condition": {"Condición", "Conditions", "Condición", "Condição"},
+
+	// ===== tui =====
+	"Locations":             {"Localizaciones", "Lieux", "Localizacións", "Localizações"},
+	"Loading…":              {"Cargando…", "Chargement…", "Cargando…", "A carregar…"},
+	"Loading %s…":           {"Cargando %s…", "Chargement de %s…", "Cargando %s…", "A carregar %s…"},
+	"Updated %s":            {"Actualizado %s", "Mis à jour %s", "Actualizado %s", "Atualizado %s"},
+	"Stale: last update %s": {"Desactualizado: último dato %s", "Périmé : dernière mise à jour %s", "Desactualizado: último dato %s", "Desatualizado: última atualização %s"},
+	"tui needs a terminal":  {"tui necesita una terminal", "tui a besoin d'un terminal", "tui necesita unha terminal", "tui precisa de um terminal"},
+	"↑↓ hours  ←→ days  Tab locations  u units  r refresh  q quit": {
+		"↑↓ horas  ←→ días  Tab localizaciones  u unidades  r refrescar  q salir",
+		"↑↓ heures  ←→ jours  Tab lieux  u unités  r rafraîchir  q quitter",
+		"↑↓ horas  ←→ días  Tab localizacións  u unidades  r actualizar  q saír",
+		"↑↓ horas  ←→ dias  Tab localizações  u unidades  r atualizar  q sair",
+	},
+
+	// ===== render: avisos y calidad del aire =====
+	"ACTIVE ALERTS (%d)":             {"AVISOS ACTIVOS (%d)", "ALERTES EN COURS (%d)", "AVISOS ACTIVOS (%d)", "AVISOS ATIVOS (%d)"},
+	"area:":                          {"zona:", "zone:", "zona:", "área:"},
+	"from":                           {"desde", "du", "desde", "de"},
+	"until":                          {"hasta", "au", "ata", "até"},
+	"Air quality:":                   {"Calidad del aire:", "Qualité de l'air:", "Calidade do aire:", "Qualidade do ar:"},
+	"Good":                           {"Buena", "Bonne", "Boa", "Boa"},
+	"Moderate":                       {"Moderada", "Modérée", "Moderada", "Moderada"},
+	"Unhealthy for sensitive groups": {"Dañina para grupos sensibles", "Mauvaise pour les groupes sensibles", "Prexudicial para grupos sensibles", "Prejudicial para grupos sensíveis"},
+	"Unhealthy":                      {"Dañina", "Mauvaise", "Prexudicial", "Prejudicial"},
+	"Very unhealthy":                 {"Muy dañina", "Très mauvaise", "Moi prexudicial", "Muito prejudicial"},
+	"Hazardous":                      {"Peligrosa", "Dangereuse", "Perigosa", "Perigosa"},
+	"Unknown":                        {"Desconocida", "Inconnue", "Descoñecida", "Desconhecida"},
+
+	// ===== render y cmd: configuración =====
+	"Setting":         {"Ajuste", "Réglage", "Axuste", "Definição"},
+	"Value":           {"Valor", "Valeur", "Valor", "Valor"},
+	"Source":          {"Origen", "Origine", "Orixe", "Origem"},
+	"flag %s":         {"flag %s", "option %s", "flag %s", "opção %s"},
+	"env %s":          {"entorno %s", "environnement %s", "contorno %s", "ambiente %s"},
+	"file":            {"fichero", "fichier", "ficheiro", "ficheiro"},
+	"default":         {"por defecto", "par défaut", "por defecto", "por omissão"},
+	"Config file: %s": {"Fichero de configuración: %s", "Fichier de configuration : %s", "Ficheiro de configuración: %s", "Ficheiro de configuração: %s"},
+	"Config file: none (%s would be used)": {
+		"Fichero de configuración: ninguno (se usaría %s)",
+		"Fichier de configuration : aucun (%s serait utilisé)",
+		"Ficheiro de configuración: ningún (usaríase %s)",
+		"Ficheiro de configuração: nenhum (seria usado %s)",
+	},
+	"%s already exists (use --force to overwrite it)": {
+		"%s ya existe (usa --force para sobrescribirlo)",
+		"%s existe déjà (utilisez --force pour l'écraser)",
+		"%s xa existe (usa --force para sobrescribilo)",
+		"%s já existe (use --force para o substituir)",
+	},
+	"Wrote %s":    {"Escrito %s", "%s écrit", "Escrito %s", "Escrito %s"},
+	"%s is valid": {"%s es válido", "%s est valide", "%s é válido", "%s é válido"},
+	"No config file; the settings are valid": {
+		"No hay fichero de configuración; los ajustes son válidos",
+		"Pas de fichier de configuration ; les réglages sont valides",
+		"Non hai ficheiro de configuración; os axustes son válidos",
+		"Não há ficheiro de configuração; as definições são válidas",
+	},
+	"unknown setting %q (expected one of %s)": {
+		"ajuste desconocido %q (se esperaba uno de %s)",
+		"réglage inconnu %q (attendu : %s)",
+		"axuste descoñecido %q (agardábase un de %s)",
+		"definição desconhecida %q (esperava-se uma de %s)",
+	},
+	"warning: %s overrides the value in the config file": {
+		"aviso: %s tiene prioridad sobre el valor del fichero de configuración",
+		"attention : %s remplace la valeur du fichier de configuration",
+		"aviso: %s ten prioridade sobre o valor do ficheiro de configuración",
+		"aviso: %s sobrepõe-se ao valor do ficheiro de configuração",
+	},
+
+	// ===== render y cmd: localizaciones guardadas =====
+	"(default)": {"(por defecto)", "(par défaut)", "(por defecto)", "(por omissão)"},
+	"No saved locations. Add one with: cliweather locations add <name> <place>": {
+		"No hay localizaciones guardadas. Añade una con: cliweather locations add <nombre> <lugar>",
+		"Aucun lieu enregistré. Ajoutez-en un avec : cliweather locations add <nom> <lieu>",
+		"Non hai localizacións gardadas. Engade unha con: cliweather locations add <nome> <lugar>",
+		"Não há locais guardados. Adicione um com: cliweather locations add <nome> <local>",
+	},
+	"no saved location named %q (there are none yet)": {
+		"no hay ninguna localización guardada llamada %q (aún no hay ninguna)",
+		"aucun lieu enregistré nommé %q (il n'y en a encore aucun)",
+		"non hai ningunha localización gardada chamada %q (aínda non hai ningunha)",
+		"não há nenhum local guardado chamado %q (ainda não há nenhum)",
+	},
+	"no saved location named %q (expected one of %s)": {
+		"no hay ninguna localización guardada llamada %q (se esperaba una de %s)",
+		"aucun lieu enregistré nommé %q (attendu : %s)",
+		"non hai ningunha localización gardada chamada %q (agardábase unha de %s)",
+		"não há nenhum local guardado chamado %q (esperava-se um de %s)",
+	},
+
+	// ===== render: comparación =====
+	"Max temp":                {"Máxima", "Max", "Máxima", "Máxima"},
+	"Min temp":                {"Mínima", "Min", "Mínima", "Mínima"},
+	"UV":                      {"UV", "UV", "UV", "UV"},
+	"* best value of the row": {"* mejor valor de la fila", "* meilleure valeur de la ligne", "* mellor valor da fila", "* melhor valor da linha"},
+
+	// ===== render: búsqueda =====
+	"No locations found.": {"No se han encontrado localizaciones.", "Aucun lieu trouvé.", "Non se atoparon localizacións.", "Nenhum local encontrado."},
+
+	// ===== proveedores: condiciones de Open-Meteo y MET Norway =====
+	"Sunny":                           {"Soleado", "Ensoleillé", "Soleado", "Sol"},
+	"Clear":                           {"Despejado", "Dégagé", "Despexado", "Céu limpo"},
+	"Partly cloudy":                   {"Parcialmente nublado", "Partiellement nuageux", "Parcialmente nubrado", "Parcialmente nublado"},
+	"Cloudy":                          {"Nublado", "Nuageux", "Nubrado", "Nublado"},
+	"Overcast":                        {"Cubierto", "Couvert", "Cuberto", "Encoberto"},
+	"Mist":                            {"Neblina", "Brume", "Brétema", "Névoa"},
+	"Patchy rain nearby":              {"Lluvia dispersa en los alrededores", "Pluie éparse à proximité", "Choiva dispersa nos arredores", "Chuva dispersa nas proximidades"},
+	"Patchy snow nearby":              {"Nieve dispersa en los alrededores", "Neige éparse à proximité", "Neve dispersa nos arredores", "Neve dispersa nas proximidades"},
+	"Patchy sleet nearby":             {"Aguanieve dispersa en los alrededores", "Neige fondue éparse à proximité", "Auganeve dispersa nos arredores", "Chuva com neve dispersa nas proximidades"},
+	"Patchy freezing drizzle nearby":  {"Llovizna helada dispersa en los alrededores", "Bruine verglaçante éparse à proximité", "Orballo xeado disperso nos arredores", "Chuvisco gelado disperso nas proximidades"},
+	"Thundery outbreaks in nearby":    {"Tormentas en los alrededores", "Orages à proximité", "Treboadas nos arredores", "Trovoadas nas proximidades"},
+	"Blowing snow":                    {"Nieve con viento", "Chasse-neige", "Neve con vento", "Neve com vento"},
+	"Blizzard":                        {"Ventisca", "Blizzard", "Ventisca", "Nevasca"},
+	"Fog":                             {"Niebla", "Brouillard", "Néboa", "Nevoeiro"},
+	"Freezing fog":                    {"Niebla helada", "Brouillard givrant", "Néboa xeada", "Nevoeiro gelado"},
+	"Patchy light drizzle":            {"Llovizna ligera dispersa", "Bruine légère éparse", "Orballo lixeiro disperso", "Chuvisco fraco disperso"},
+	"Light drizzle":                   {"Llovizna ligera", "Bruine légère", "Orballo lixeiro", "Chuvisco fraco"},
+	"Freezing drizzle":                {"Llovizna helada", "Bruine verglaçante", "Orballo xeado", "Chuvisco gelado"},
+	"Heavy freezing drizzle":          {"Llovizna helada intensa", "Forte bruine verglaçante", "Orballo xeado intenso", "Chuvisco gelado forte"},
+	"Patchy light rain":               {"Lluvia ligera dispersa", "Pluie légère éparse", "Choiva lixeira dispersa", "Chuva fraca dispersa"},
+	"Light rain":                      {"Lluvia ligera", "Pluie légère", "Choiva lixeira", "Chuva fraca"},
+	"Moderate rain at times":          {"Lluvia moderada a ratos", "Pluie modérée par moments", "Choiva moderada por momentos", "Chuva moderada por vezes"},
+	"Moderate rain":                   {"Lluvia moderada", "Pluie modérée", "Choiva moderada", "Chuva moderada"},
+	"Heavy rain at times":             {"Lluvia fuerte a ratos", "Forte pluie par moments", "Choiva forte por momentos", "Chuva forte por vezes"},
+	"Heavy rain":                      {"Lluvia fuerte", "Forte pluie", "Choiva forte", "Chuva forte"},
+	"Light freezing rain":             {"Lluvia helada ligera", "Pluie verglaçante légère", "Choiva xeada lixeira", "Chuva gelada fraca"},
+	"Moderate or heavy freezing rain": {"Lluvia helada moderada o fuerte", "Pluie verglaçante modérée ou forte", "Choiva xeada moderada ou forte", "Chuva gelada moderada ou forte"},
+	"Light sleet":                     {"Aguanieve ligera", "Neige fondue légère", "Auganeve lixeira", "Chuva com neve fraca"},
+	"Moderate or heavy sleet":         {"Aguanieve moderada o fuerte", "Neige fondue modérée ou forte", "Auganeve moderada ou forte", "Chuva com neve moderada ou forte"},
+	"Patchy light snow":               {"Nevada ligera dispersa", "Faible neige éparse", "Nevarada lixeira dispersa", "Neve fraca dispersa"},
+	"Light snow":                      {"Nevada ligera", "Faible neige", "Nevarada lixeira", "Neve fraca"},
+	"Patchy moderate snow":            {"Nevada moderada dispersa", "Neige modérée éparse", "Nevarada moderada dispersa", "Neve moderada dispersa"},
+	"Moderate snow":                   {"Nevada moderada", "Neige modérée", "Nevarada moderada", "Neve moderada"},
+	"Patchy heavy snow":               {"Nevada fuerte dispersa", "Forte neige éparse", "Nevarada forte dispersa", "Neve forte dispersa"},
+	"Heavy snow":                      {"Nevada fuerte", "Forte neige", "Nevarada forte", "Neve forte"},
+	"Ice pellets":                     {"Gránulos de hielo", "Granules de glace", "Gránulos de xeo", "Granizo miúdo"},
+	"Light rain shower":               {"Chubasco ligero", "Averse de pluie légère", "Chuvasco lixeiro", "Aguaceiro fraco"},
+	"Moderate or heavy rain shower":   {"Chubasco moderado o fuerte", "Averse de pluie modérée ou forte", "Chuvasco moderado ou forte", "Aguaceiro moderado ou forte"},
+	"Torrential rain shower":          {"Chubasco torrencial", "Averse torrentielle", "Chuvasco torrencial", "Aguaceiro torrencial"},
+	"Light sleet showers":             {"Chubascos ligeros de aguanieve", "Averses de neige fondue légères", "Chuvascos lixeiros de auganeve", "Aguaceiros fracos de chuva com neve"},
+	"Moderate or heavy sleet showers": {"Chubascos de aguanieve moderados o fuertes", "Averses de neige fondue modérées ou fortes", "Chuvascos de auganeve moderados ou fortes", "Aguaceiros de chuva com neve moderados ou fortes"},
+	"Light snow showers":              {"Chubascos ligeros de nieve", "Averses de neige légères", "Chuvascos lixeiros de neve", "Aguaceiros fracos de neve"},
+	"Moderate or heavy snow showers":  {"Chubascos de nieve moderados o fuertes", "Averses de neige modérées ou fortes", "Chuvascos de neve moderados ou fortes", "Aguaceiros de neve moderados ou fortes"},
+	"Light showers of ice pellets":    {"Chubascos ligeros de gránulos de hielo", "Averses légères de granules de glace", "Chuvascos lixeiros de gránulos de xeo", "Aguaceiros fracos de granizo miúdo"},
+	"Moderate or heavy showers of ice pellets": {"Chubascos de gránulos de hielo moderados o fuertes", "Averses de granules de glace modérées ou fortes", "Chuvascos de gránulos de xeo moderados ou fortes", "Aguaceiros de granizo miúdo moderados ou fortes"},
+	"Patchy light rain with thunder":           {"Lluvia ligera dispersa con tormenta", "Pluie légère éparse avec orage", "Choiva lixeira dispersa con treboada", "Chuva fraca dispersa com trovoada"},
+	"Moderate or heavy rain with thunder":      {"Lluvia moderada o fuerte con tormenta", "Pluie modérée ou forte avec orage", "Choiva moderada ou forte con treboada", "Chuva moderada ou forte com trovoada"},
+	"Patchy light snow with thunder":           {"Nevada ligera dispersa con tormenta", "Faible neige éparse avec orage", "Nevarada lixeira dispersa con treboada", "Neve fraca dispersa com trovoada"},
+	"Moderate or heavy snow with thunder":      {"Nevada moderada o fuerte con tormenta", "Neige modérée ou forte avec orage", "Nevarada moderada ou forte con treboada", "Neve moderada ou forte com trovoada"},
+
+	// ===== cmd: errores y avisos =====
+	"error: %s": {"error: %s", "erreur : %s", "erro: %s", "erro: %s"},
+	"missing API key: export WEATHER_API_KEY=your_api_key or use --apikey": {
+		"falta la API key: exporta WEATHER_API_KEY=tu_api_key o usa --apikey",
+		"clé d'API manquante : exportez WEATHER_API_KEY=votre_clé ou utilisez --apikey",
+		"falta a API key: exporta WEATHER_API_KEY=a_tua_api_key ou usa --apikey",
+		"falta a chave da API: exporte WEATHER_API_KEY=a_sua_chave ou use --apikey",
+	},
+	"the WeatherAPI key is not valid": {"la API key de WeatherAPI no es válida", "la clé WeatherAPI n'est pas valide", "a API key de WeatherAPI non é válida", "a chave da WeatherAPI não é válida"},
+	"the WeatherAPI key is disabled":  {"la API key de WeatherAPI está desactivada", "la clé WeatherAPI est désactivée", "a API key de WeatherAPI está desactivada", "a chave da WeatherAPI está desativada"},
+	"the monthly WeatherAPI quota has been exhausted": {
+		"se ha agotado la cuota mensual de WeatherAPI",
+		"le quota mensuel de WeatherAPI est épuisé",
+		"esgotouse a cota mensual de WeatherAPI",
+		"a quota mensal da WeatherAPI esgotou-se",
+	},
+	"no location found for that query": {
+		"no se ha encontrado ninguna localización para esa búsqueda",
+		"aucun lieu trouvé pour cette recherche",
+		"non se atopou ningunha localización para esa busca",
+		"nenhum local encontrado para essa pesquisa",
+	},
+	"your WeatherAPI plan does not give access to this resource": {
+		"tu plan de WeatherAPI no da acceso a este recurso",
+		"votre offre WeatherAPI ne donne pas accès à cette ressource",
+		"o teu plan de WeatherAPI non dá acceso a este recurso",
+		"o seu plano da WeatherAPI não dá acesso a este recurso",
+	},
+	"invalid request: %v": {"petición inválida: %v", "requête invalide : %v", "petición non válida: %v", "pedido inválido: %v"},
+	"WeatherAPI is not available right now, try again later": {
+		"WeatherAPI no está disponible ahora mismo, inténtalo más tarde",
+		"WeatherAPI n'est pas disponible pour le moment, réessayez plus tard",
+		"WeatherAPI non está dispoñible agora mesmo, téntao máis tarde",
+		"a WeatherAPI não está disponível neste momento, tente mais tarde",
+	},
+	"the selected provider does not support this operation (%v)": {
+		"el proveedor elegido no soporta esta operación (%v)",
+		"le fournisseur choisi ne prend pas en charge cette opération (%v)",
+		"o provedor escollido non admite esta operación (%v)",
+		"o fornecedor escolhido não suporta esta operação (%v)",
+	},
+	"timed out contacting the service": {
+		"tiempo de espera agotado al contactar con el servicio",
+		"délai d'attente dépassé en contactant le service",
+		"esgotouse o tempo de espera ao contactar co servizo",
+		"tempo de espera esgotado ao contactar o serviço",
+	},
+	"unknown setting %q in %s": {
+		"ajuste desconocido %q en %s",
+		"réglage inconnu %q dans %s",
+		"axuste descoñecido %q en %s",
+		"definição desconhecida %q em %s",
+	},
+	"invalid %s %q (from %s): %v": {
+		"%s no válido: %q (de %s): %v",
+		"%s non valide : %q (depuis %s) : %v",
+		"%s non válido: %q (de %s): %v",
+		"%s inválido: %q (de %s): %v",
+	},
+	"warning: skipping %s (%v)": {
+		"aviso: se omite %s (%v)",
+		"attention : %s ignoré (%v)",
+		"aviso: omítese %s (%v)",
+		"aviso: %s ignorado (%v)",
+	},
+	"warning: %s failed (%v), trying the next provider": {
+		"aviso: %s falló (%v), probando el siguiente proveedor",
+		"attention : %s a échoué (%v), essai du fournisseur suivant",
+		"aviso: %s fallou (%v), probando o seguinte provedor",
+		"aviso: %s falhou (%v), a tentar o fornecedor seguinte",
+	},
+	"invalid --from date %q (expected YYYY-MM-DD)": {
+		"fecha --from %q no válida (se espera AAAA-MM-DD)",
+		"date --from %q invalide (format attendu AAAA-MM-JJ)",
+		"data --from %q non válida (espérase AAAA-MM-DD)",
+		"data --from %q inválida (formato esperado AAAA-MM-DD)",
+	},
+	"invalid --to date %q (expected YYYY-MM-DD)": {
+		"fecha --to %q no válida (se espera AAAA-MM-DD)",
+		"date --to %q invalide (format attendu AAAA-MM-JJ)",
+		"data --to %q non válida (espérase AAAA-MM-DD)",
+		"data --to %q inválida (formato esperado AAAA-MM-DD)",
+	},
+	"--to (%s) is before --from (%s)": {
+		"--to (%s) es anterior a --from (%s)",
+		"--to (%s) est antérieur à --from (%s)",
+		"--to (%s) é anterior a --from (%s)",
+		"--to (%s) é anterior a --from (%s)",
+	},
+	"range too long: %d days (max %d)": {
+		"rango demasiado largo: %d días (máximo %d)",
+		"plage trop longue : %d jours (max %d)",
+		"intervalo demasiado longo: %d días (máximo %d)",
+		"intervalo demasiado longo: %d dias (máximo %d)",
+	},
+	"unsupported --output %q (expected %s)": {
+		"--output %q no soportado (se espera %s)",
+		"--output %q non pris en charge (attendu : %s)",
+		"--output %q non admitido (espérase %s)",
+		"--output %q não suportado (esperado %s)",
+	},
+	"unsupported --granularity %q (expected hourly or daily)": {
+		"--granularity %q no soportado (se espera hourly o daily)",
+		"--granularity %q non pris en charge (attendu : hourly ou daily)",
+		"--granularity %q non admitido (espérase hourly ou daily)",
+		"--granularity %q não suportado (esperado hourly ou daily)",
+	},
+	"--template cannot be combined with --output %s": {
+		"--template no se puede combinar con --output %s",
+		"--template ne peut pas être combiné avec --output %s",
+		"--template non se pode combinar con --output %s",
+		"--template não pode ser combinado com --output %s",
+	},
+	"invalid template: %v":                          {"plantilla no válida: %v", "modèle invalide : %v", "modelo non válido: %v", "modelo inválido: %v"},
+	"refresh interval must be at least %s":          {"el intervalo de refresco debe ser de al menos %s", "l'intervalle de rafraîchissement doit être d'au moins %s", "o intervalo de actualización debe ser de polo menos %s", "o intervalo de atualização deve ser de pelo menos %s"},
+	"watch needs a terminal":                        {"watch necesita una terminal", "watch a besoin d'un terminal", "watch necesita unha terminal", "watch precisa de um terminal"},
+	"--watch only works with --output text":         {"--watch solo funciona con --output text", "--watch ne fonctionne qu'avec --output text", "--watch só funciona con --output text", "--watch só funciona com --output text"},
+	"--watch only works with a single location":     {"--watch solo funciona con una localización", "--watch ne fonctionne qu'avec un seul lieu", "--watch só funciona cunha localización", "--watch só funciona com um local"},
+	"--output %s only works with a single location": {"--output %s solo funciona con una localización", "--output %s ne fonctionne qu'avec un seul lieu", "--output %s só funciona cunha localización", "--output %s só funciona com um local"},
+	"--all-favorites needs saved locations (see cliweather locations add)": {
+		"--all-favorites necesita localizaciones guardadas (ver cliweather locations add)",
+		"--all-favorites nécessite des lieux enregistrés (voir cliweather locations add)",
+		"--all-favorites necesita localizacións gardadas (ver cliweather locations add)",
+		"--all-favorites precisa de locais guardados (ver cliweather locations add)",
+	},
+	"warning: %s: %s": {"aviso: %s: %s", "attention : %s : %s", "aviso: %s: %s", "aviso: %s: %s"},
+	"%d of %d locations could not be fetched": {
+		"no se pudieron consultar %d de %d localizaciones",
+		"%d lieux sur %d n'ont pas pu être consultés",
+		"non se puideron consultar %d de %d localizacións",
+		"não foi possível consultar %d de %d locais",
+	},
+	"Updated %s · next refresh in %s · Ctrl-C to quit": {
+		"Actualizado %s · próximo refresco en %s · Ctrl-C para salir",
+		"Mis à jour %s · prochain rafraîchissement dans %s · Ctrl-C pour quitter",
+		"Actualizado %s · próxima actualización en %s · Ctrl-C para saír",
+		"Atualizado %s · próxima atualização em %s · Ctrl-C para sair",
+	},
+	"No data yet · %s · retrying in %s": {
+		"Aún sin datos · %s · reintentando en %s",
+		"Pas encore de données · %s · nouvel essai dans %s",
+		"Aínda sen datos · %s · reintentando en %s",
+		"Ainda sem dados · %s · nova tentativa em %s",
+	},
+	"Stale: last update %s (%s ago) · %s · retrying in %s": {
+		"Desactualizado: último dato %s (hace %s) · %s · reintentando en %s",
+		"Périmé : dernière mise à jour %s (il y a %s) · %s · nouvel essai dans %s",
+		"Desactualizado: último dato %s (hai %s) · %s · reintentando en %s",
+		"Desatualizado: última atualização %s (há %s) · %s · nova tentativa em %s",
+	},
+	"unsupported --chart %q (expected spark or line)": {
+		"--chart %q no soportado (se espera spark o line)",
+		"--chart %q non pris en charge (attendu : spark ou line)",
+		"--chart %q non admitido (espérase spark ou line)",
+		"--chart %q não suportado (esperado spark ou line)",
+	},
+	"unsupported shell: %s": {"shell no soportada: %s", "shell non pris en charge : %s", "shell non admitida: %s", "shell não suportada: %s"},
+
+	// ===== cmd: ayuda de cobra =====
+	"Usage:":                  {"Uso:", "Utilisation :", "Uso:", "Utilização:"},
+	"Aliases:":                {"Alias:", "Alias :", "Alias:", "Aliases:"},
+	"Examples:":               {"Ejemplos:", "Exemples :", "Exemplos:", "Exemplos:"},
+	"Available Commands:":     {"Comandos disponibles:", "Commandes disponibles :", "Comandos dispoñibles:", "Comandos disponíveis:"},
+	"Flags:":                  {"Opciones:", "Options :", "Opcións:", "Opções:"},
+	"Global Flags:":           {"Opciones globales:", "Options globales :", "Opcións globais:", "Opções globais:"},
+	"Additional help topics:": {"Otros temas de ayuda:", "Autres sujets d'aide :", "Outros temas de axuda:", "Outros tópicos de ajuda:"},
+	"Use \"%s [command] --help\" for more information about a command.": {
+		"Usa \"%s [comando] --help\" para más información sobre un comando.",
+		"Utilisez \"%s [commande] --help\" pour plus d'informations sur une commande.",
+		"Usa \"%s [comando] --help\" para máis información sobre un comando.",
+		"Use \"%s [comando] --help\" para mais informações sobre um comando.",
+	},
+	"help for %s":            {"ayuda de %s", "aide pour %s", "axuda de %s", "ajuda para %s"},
+	"Help about any command": {"Ayuda sobre cualquier comando", "Aide sur n'importe quelle commande", "Axuda sobre calquera comando", "Ajuda sobre qualquer comando"},
+
+	"Simple, handy weather CLI":                         {"CLI del tiempo sencilla y práctica", "CLI météo simple et pratique", "CLI do tempo sinxela e práctica", "CLI do tempo simples e prática"},
+	"Show the weather forecast":                         {"Muestra la previsión meteorológica", "Affiche les prévisions météo", "Amosa a predición meteorolóxica", "Mostra a previsão meteorológica"},
+	"Show the current weather":                          {"Muestra el tiempo actual", "Affiche la météo actuelle", "Amosa o tempo actual", "Mostra o tempo atual"},
+	"Show observed weather for a date range":            {"Muestra el tiempo observado en un rango de fechas", "Affiche la météo observée sur une période", "Amosa o tempo observado nun intervalo de datas", "Mostra o tempo observado num intervalo de datas"},
+	"Search locations matching a text":                  {"Busca localizaciones que encajen con un texto", "Cherche les lieux correspondant à un texte", "Busca localizacións que encaixen cun texto", "Procura locais que correspondam a um texto"},
+	"Show the binary version":                           {"Muestra la versión del binario", "Affiche la version du binaire", "Amosa a versión do binario", "Mostra a versão do binário"},
+	"Generate the autocompletion script for your shell": {"Genera el script de autocompletado para tu shell", "Génère le script d'autocomplétion pour votre shell", "Xera o script de autocompletado para a túa shell", "Gera o script de autocompletar para a sua shell"},
+	"Generate shell autocompletion for cliweather.":     {"Genera autocompletado para cliweather.", "Génère l'autocomplétion de cliweather.", "Xera o autocompletado para cliweather.", "Gera o autocompletar para o cliweather."},
+
+	"Config file in YAML or TOML (default $XDG_CONFIG_HOME/cliweather/config.yaml, or CLIWEATHER_CONFIG)": {
+		"Fichero de configuración YAML o TOML (por defecto $XDG_CONFIG_HOME/cliweather/config.yaml, o CLIWEATHER_CONFIG)",
+		"Fichier de configuration YAML ou TOML (par défaut $XDG_CONFIG_HOME/cliweather/config.yaml, ou CLIWEATHER_CONFIG)",
+		"Ficheiro de configuración YAML ou TOML (por defecto $XDG_CONFIG_HOME/cliweather/config.yaml, ou CLIWEATHER_CONFIG)",
+		"Ficheiro de configuração YAML ou TOML (por omissão $XDG_CONFIG_HOME/cliweather/config.yaml, ou CLIWEATHER_CONFIG)",
+	},
+	"Disable ANSI colours in the output":                                {"Desactivar colores ANSI en la salida", "Désactiver les couleurs ANSI", "Desactivar as cores ANSI na saída", "Desativar as cores ANSI na saída"},
+	"Disable emoji in the output":                                       {"Desactivar emojis en la salida", "Désactiver les emojis", "Desactivar os emojis na saída", "Desativar os emojis na saída"},
+	"Unit system: metric, imperial or uk (or WEATHER_UNITS)":            {"Sistema de unidades: metric, imperial o uk (o WEATHER_UNITS)", "Système d'unités : metric, imperial ou uk (ou WEATHER_UNITS)", "Sistema de unidades: metric, imperial ou uk (ou WEATHER_UNITS)", "Sistema de unidades: metric, imperial ou uk (ou WEATHER_UNITS)"},
+	"Wind unit: kmh, mph, ms, knots or beaufort (or WEATHER_WIND_UNIT)": {"Unidad de viento: kmh, mph, ms, knots o beaufort (o WEATHER_WIND_UNIT)", "Unité de vent : kmh, mph, ms, knots ou beaufort (ou WEATHER_WIND_UNIT)", "Unidade de vento: kmh, mph, ms, knots ou beaufort (ou WEATHER_WIND_UNIT)", "Unidade de vento: kmh, mph, ms, knots ou beaufort (ou WEATHER_WIND_UNIT)"},
+	"Condition icon set: emoji, nerd or ascii (or WEATHER_ICONS)": {
+		"Juego de iconos de condición: emoji, nerd o ascii (o WEATHER_ICONS)",
+		"Jeu d'icônes des conditions : emoji, nerd ou ascii (ou WEATHER_ICONS)",
+		"Xogo de iconas de condición: emoji, nerd ou ascii (ou WEATHER_ICONS)",
+		"Conjunto de ícones de condição: emoji, nerd ou ascii (ou WEATHER_ICONS)",
+	},
+	"JSON file with custom condition icons (or WEATHER_ICONS_FILE)": {
+		"Fichero JSON con iconos de condición propios (o WEATHER_ICONS_FILE)",
+		"Fichier JSON d'icônes de conditions personnalisées (ou WEATHER_ICONS_FILE)",
+		"Ficheiro JSON con iconas de condición propias (ou WEATHER_ICONS_FILE)",
+		"Ficheiro JSON com ícones de condição personalizados (ou WEATHER_ICONS_FILE)",
+	},
+	"City name or lat,lon query (default: location in the config file, or Vigo)": {
+		"Ciudad o coordenadas lat,lon (por defecto: location del fichero de configuración, o Vigo)",
+		"Ville ou coordonnées lat,lon (par défaut : location du fichier de configuration, ou Vigo)",
+		"Cidade ou coordenadas lat,lon (por defecto: location do ficheiro de configuración, ou Vigo)",
+		"Cidade ou coordenadas lat,lon (por omissão: location do ficheiro de configuração, ou Vigo)",
+	},
+	"Language for labels and conditions: es, en, fr, gl or pt (or WEATHER_LANG)": {
+		"Idioma de etiquetas y condiciones: es, en, fr, gl o pt (o WEATHER_LANG)",
+		"Langue des libellés et des conditions : es, en, fr, gl ou pt (ou WEATHER_LANG)",
+		"Idioma das etiquetas e condicións: es, en, fr, gl ou pt (ou WEATHER_LANG)",
+		"Idioma das etiquetas e condições: es, en, fr, gl ou pt (ou WEATHER_LANG)",
+	},
+	"WeatherAPI key (or set WEATHER_API_KEY)": {"API key de WeatherAPI (o WEATHER_API_KEY)", "Clé WeatherAPI (ou WEATHER_API_KEY)", "API key de WeatherAPI (ou WEATHER_API_KEY)", "Chave da WeatherAPI (ou WEATHER_API_KEY)"},
+	"Weather provider or ordered fallback list, e.g. weatherapi,openmeteo (or set WEATHER_PROVIDER)": {
+		"Proveedor o lista ordenada de respaldo, p. ej. weatherapi,openmeteo (o WEATHER_PROVIDER)",
+		"Fournisseur ou liste ordonnée de secours, p. ex. weatherapi,openmeteo (ou WEATHER_PROVIDER)",
+		"Provedor ou lista ordenada de respaldo, p. ex. weatherapi,openmeteo (ou WEATHER_PROVIDER)",
+		"Fornecedor ou lista ordenada de recurso, p. ex. weatherapi,openmeteo (ou WEATHER_PROVIDER)",
+	},
+	"Shorthand for --output json":                                       {"Atajo de --output json", "Raccourci pour --output json", "Atallo de --output json", "Atalho para --output json"},
+	"Rows per hour or per day for csv, tsv and ndjson: hourly or daily": {"Filas por hora o por día en csv, tsv y ndjson: hourly o daily", "Lignes par heure ou par jour pour csv, tsv et ndjson : hourly ou daily", "Filas por hora ou por día en csv, tsv e ndjson: hourly ou daily", "Linhas por hora ou por dia em csv, tsv e ndjson: hourly ou daily"},
+	"Go text/template to render, inline or a built-in name: %s":         {"Plantilla text/template de Go, en línea o el nombre de una incluida: %s", "Modèle text/template de Go, en ligne ou le nom d'un modèle intégré : %s", "Modelo text/template de Go, en liña ou o nome dun incluído: %s", "Modelo text/template de Go, em linha ou o nome de um incluído: %s"},
+	"File with a Go text/template to render":                            {"Fichero con una plantilla text/template de Go", "Fichier contenant un modèle text/template de Go", "Ficheiro cun modelo text/template de Go", "Ficheiro com um modelo text/template de Go"},
+	"Refresh the forecast in place on an interval":                      {"Refrescar la previsión en pantalla cada cierto tiempo", "Rafraîchir la prévision sur place à intervalles réguliers", "Actualizar a predición en pantalla cada certo tempo", "Atualizar a previsão no ecrã a cada intervalo"},
+	"Time between refreshes, e.g. 5m or 1h":                             {"Tiempo entre refrescos, p. ej. 5m o 1h", "Temps entre deux rafraîchissements, p. ex. 5m ou 1h", "Tempo entre actualizacións, p. ex. 5m ou 1h", "Tempo entre atualizações, p. ex. 5m ou 1h"},
+	"Redraw the forecast in place every interval, e.g. 10m":             {"Redibujar la previsión en pantalla cada intervalo, p. ej. 10m", "Redessiner la prévision sur place à chaque intervalle, p. ex. 10m", "Redebuxar a predición en pantalla cada intervalo, p. ex. 10m", "Redesenhar a previsão no ecrã a cada intervalo, p. ex. 10m"},
+	"Browse days, hours and locations interactively":                    {"Explorar días, horas y localizaciones de forma interactiva", "Parcourir jours, heures et lieux de manière interactive", "Explorar días, horas e localizacións de forma interactiva", "Explorar dias, horas e localizações de forma interativa"},
+	"Inspect and edit the configuration":                                {"Consultar y editar la configuración", "Consulter et modifier la configuration", "Consultar e editar a configuración", "Consultar e editar a configuração"},
+	"Show every setting with its value and source":                      {"Mostrar cada ajuste con su valor y su origen", "Afficher chaque réglage avec sa valeur et son origine", "Amosar cada axuste co seu valor e a súa orixe", "Mostrar cada definição com o seu valor e origem"},
+	"Print the value in effect for a setting":                           {"Mostrar el valor efectivo de un ajuste", "Afficher la valeur effective d'un réglage", "Amosar o valor efectivo dun axuste", "Mostrar o valor efetivo de uma definição"},
+	"Save a setting in the config file":                                 {"Guardar un ajuste en el fichero de configuración", "Enregistrer un réglage dans le fichier de configuration", "Gardar un axuste no ficheiro de configuración", "Guardar uma definição no ficheiro de configuração"},
+	"Remove a setting from the config file":                             {"Quitar un ajuste del fichero de configuración", "Retirer un réglage du fichier de configuration", "Quitar un axuste do ficheiro de configuración", "Remover uma definição do ficheiro de configuração"},
+	"Print the path of the config file":                                 {"Mostrar la ruta del fichero de configuración", "Afficher le chemin du fichier de configuration", "Amosar a ruta do ficheiro de configuración", "Mostrar o caminho do ficheiro de configuração"},
+	"Write a commented config file with the defaults":                   {"Escribir un fichero de configuración comentado con los valores por defecto", "Écrire un fichier de configuration commenté avec les valeurs par défaut", "Escribir un ficheiro de configuración comentado cos valores por defecto", "Escrever um ficheiro de configuração comentado com os valores por omissão"},
+	"Check the config file and the CLIWEATHER_* variables":              {"Comprobar el fichero de configuración y las variables CLIWEATHER_*", "Vérifier le fichier de configuration et les variables CLIWEATHER_*", "Comprobar o ficheiro de configuración e as variables CLIWEATHER_*", "Verificar o ficheiro de configuração e as variáveis CLIWEATHER_*"},
+	"City name or lat,lon query; repeat it for several (default: location in the config file, or Vigo)": {
+		"Ciudad o coordenadas lat,lon; repítelo para varias (por defecto: location del fichero de configuración, o Vigo)",
+		"Ville ou coordonnées lat,lon ; à répéter pour plusieurs (par défaut : location du fichier de configuration, ou Vigo)",
+		"Cidade ou coordenadas lat,lon; repíteo para varias (por defecto: location do ficheiro de configuración, ou Vigo)",
+		"Cidade ou coordenadas lat,lon; repita-o para várias (por omissão: location do ficheiro de configuração, ou Vigo)",
+	},
+	"Show every saved location (see cliweather locations)":   {"Mostrar todas las localizaciones guardadas (ver cliweather locations)", "Afficher tous les lieux enregistrés (voir cliweather locations)", "Amosar todas as localizacións gardadas (ver cliweather locations)", "Mostrar todos os locais guardados (ver cliweather locations)"},
+	"Compare the forecast of several locations side by side": {"Comparar la previsión de varias localizaciones lado a lado", "Comparer côte à côte les prévisions de plusieurs lieux", "Comparar a predición de varias localizacións lado a lado", "Comparar lado a lado a previsão de vários locais"},
+	"Manage saved locations":                                 {"Gestionar las localizaciones guardadas", "Gérer les lieux enregistrés", "Xestionar as localizacións gardadas", "Gerir os locais guardados"},
+	"Save a place under a name":                              {"Guardar un lugar con un nombre", "Enregistrer un lieu sous un nom", "Gardar un lugar cun nome", "Guardar um local com um nome"},
+	"List the saved locations":                               {"Listar las localizaciones guardadas", "Lister les lieux enregistrés", "Listar as localizacións gardadas", "Listar os locais guardados"},
+	"Forget a saved location":                                {"Olvidar una localización guardada", "Oublier un lieu enregistré", "Esquecer unha localización gardada", "Esquecer um local guardado"},
+	"Rename a saved location":                                {"Renombrar una localización guardada", "Renommer un lieu enregistré", "Renomear unha localización gardada", "Renomear um local guardado"},
+	"Use a saved location when --city is not given":          {"Usar una localización guardada cuando no se indica --city", "Utiliser un lieu enregistré quand --city n'est pas indiqué", "Usar unha localización gardada cando non se indica --city", "Usar um local guardado quando --city não é indicado"},
+	"Also make it the default location":                      {"Hacerla además la localización por defecto", "En faire aussi le lieu par défaut", "Facela ademais a localización por defecto", "Torná-lo também o local por omissão"},
+	"Overwrite an existing config file":                      {"Sobrescribir un fichero de configuración existente", "Écraser un fichier de configuration existant", "Sobrescribir un ficheiro de configuración existente", "Substituir um ficheiro de configuração existente"},
+	"Chart temperature, rain chance and wind instead of listing hours: spark or line": {"Graficar temperatura, probabilidad de lluvia y viento en lugar de listar las horas: spark o line", "Tracer température, risque de pluie et vent au lieu de lister les heures : spark ou line", "Graficar temperatura, probabilidade de choiva e vento en lugar de listar as horas: spark ou line", "Desenhar temperatura, probabilidade de chuva e vento em vez de listar as horas: spark ou line"},
+	"Output format: %s":                               {"Formato de salida: %s", "Format de sortie : %s", "Formato de saída: %s", "Formato de saída: %s"},
+	"Do not read or write the response cache":         {"No leer ni escribir la caché de respuestas", "Ne pas lire ni écrire le cache des réponses", "Non ler nin escribir a caché de respostas", "Não ler nem escrever a cache de respostas"},
+	"Ignore cached responses but store the fresh one": {"Ignorar la caché pero guardar la respuesta nueva", "Ignorer le cache mais enregistrer la nouvelle réponse", "Ignorar a caché pero gardar a resposta nova", "Ignorar a cache mas guardar a resposta nova"},
+	"Forecast days (1-3 on free tier)":                {"Días de previsión (1-3 en el plan gratuito)", "Jours de prévision (1-3 en offre gratuite)", "Días de predición (1-3 no plan gratuíto)", "Dias de previsão (1-3 no plano gratuito)"},
+	"Print raw structs for debugging":                 {"Mostrar las estructuras en bruto para depurar", "Afficher les structures brutes pour le débogage", "Amosar as estruturas en bruto para depurar", "Mostrar as estruturas em bruto para depuração"},
+	"Show only this forecast day index (0..days-1)":   {"Mostrar solo el día con este índice (0..días-1)", "N'afficher que le jour de cet indice (0..jours-1)", "Amosar só o día con este índice (0..días-1)", "Mostrar só o dia com este índice (0..dias-1)"},
+	"Include air quality (weatherapi)":                {"Incluir la calidad del aire (weatherapi)", "Inclure la qualité de l'air (weatherapi)", "Incluír a calidade do aire (weatherapi)", "Incluir a qualidade do ar (weatherapi)"},
+	"Include official weather alerts (weatherapi)":    {"Incluir los avisos meteorológicos oficiales (weatherapi)", "Inclure les alertes météo officielles (weatherapi)", "Incluír os avisos meteorolóxicos oficiais (weatherapi)", "Incluir os avisos meteorológicos oficiais (weatherapi)"},
+	"First day (YYYY-MM-DD)":                          {"Primer día (AAAA-MM-DD)", "Premier jour (AAAA-MM-JJ)", "Primeiro día (AAAA-MM-DD)", "Primeiro dia (AAAA-MM-DD)"},
+	"Last day (YYYY-MM-DD, defaults to --from)":       {"Último día (AAAA-MM-DD, por defecto --from)", "Dernier jour (AAAA-MM-JJ, --from par défaut)", "Último día (AAAA-MM-DD, por defecto --from)", "Último dia (AAAA-MM-DD, por omissão --from)"},
+}
+
+// builder es el catálogo de x/text construido a partir de messages
+var builder = func() *catalog.Builder {
+	b := catalog.NewBuilder(catalog.Fallback(language.English))
+	for key, t := range messages {
+		_ = b.SetString(language.Spanish, key, t.es)
+		_ = b.SetString(language.French, key, t.fr)
+		_ = b.SetString(language.MustParse("gl"), key, t.gl)
+		_ = b.SetString(language.Portuguese, key, t.pt)
+	}
+	return b
+}()
@@ -0,0 +1,37 @@
+package i18n
+
+// dateNames contiene los nombres de días y meses de un idioma. Los días
+// empiezan en domingo, como time.Weekday.
+type dateNames struct {
+	weekdays      [7]string
+	shortWeekdays [7]string
+	shortMonths   [12]string
+}
+
+var names = map[string]dateNames{
+	"en": {
+		weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		shortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	},
+	"es": {
+		weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		shortMonths:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+	},
+	"fr": {
+		weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortWeekdays: [7]string{"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+		shortMonths:   [12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+	},
+	"gl": {
+		weekdays:      [7]string{"domingo", "luns", "martes", "mércores", "xoves", "venres", "sábado"},
+		shortWeekdays: [7]string{"dom", "lun", "mar", "mér", "xov", "ven", "sáb"},
+		shortMonths:   [12]string{"xan", "feb", "mar", "abr", "mai", "xuñ", "xul", "ago", "set", "out", "nov", "dec"},
+	},
+	"pt": {
+		weekdays:      [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		shortWeekdays: [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		shortMonths:   [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+	},
+}
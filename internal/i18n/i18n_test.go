@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNew_Matching(t *testing.T) {
+	cases := map[string]string{
+		"":            "es",
+		"es":          "es",
+		"es_ES.UTF-8": "es",
+		"pt-BR":       "pt",
+		"gl":          "gl",
+		"fr-CA":       "fr",
+		"en-GB":       "en",
+		"de":          "en", // sin catálogo: las claves en inglés
+		"C":           "en",
+	}
+	for in, want := range cases {
+		if got := New(in).Lang(); got != want {
+			t.Errorf("New(%q).Lang() = %q, want %q", in, got, want)
+		}
+	}
+}
+
+var verbRe = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// Cada clave debe estar traducida a todos los idiomas con los mismos verbos
+func TestCatalog_Complete(t *testing.T) {
+	for key, tr := range messages {
+		want := verbRe.FindAllString(key, -1)
+		for lang, s := range map[string]string{"es": tr.es, "fr": tr.fr, "gl": tr.gl, "pt": tr.pt} {
+			if s == "" {
+				t.Errorf("%q: missing %s translation", key, lang)
+				continue
+			}
+			if got := verbRe.FindAllString(s, -1); !slices.Equal(got, want) {
+				t.Errorf("%q (%s): verbs %v, want %v", key, lang, got, want)
+			}
+		}
+	}
+}
+
+func TestSprintf_Numbers(t *testing.T) {
+	cases := []struct {
+		lang, format string
+		arg          any
+		want         string
+	}{
+		{"en", "%.1f mm", 12.34, "12.3 mm"},
+		{"es", "%.1f mm", 12.34, "12,3 mm"},
+		{"pt", "%.2f inHg", 29.92, "29,92 inHg"},
+		{"es", "%.0f hPa", 1023.0, "1023 hPa"}, // sin separador de miles
+		{"es", "%d%%", 82, "82%"},
+		{"en", "%.0f m", 12345.0, "12,345 m"},
+	}
+	for _, c := range cases {
+		if got := New(c.lang).Sprintf(c.format, c.arg); got != c.want {
+			t.Errorf("%s Sprintf(%q, %v) = %q, want %q", c.lang, c.format, c.arg, got, c.want)
+		}
+	}
+}
+
+func TestSprintf_Translates(t *testing.T) {
+	if got := New("es").Sprintf("Summary (%d days)", 7); got != "Resumen (7 días)" {
+		t.Errorf("got %q", got)
+	}
+	if got := New("en").Sprintf("Summary (%d days)", 7); got != "Summary (7 days)" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestDates(t *testing.T) {
+	d := time.Date(2026, 10, 19, 9, 5, 0, 0, time.UTC)
+	cases := map[string][2]string{
+		"en": {"Monday", "Mon 19 Oct 2026"},
+		"es": {"lunes", "lun 19 oct 2026"},
+		"fr": {"lundi", "lun 19 oct 2026"},
+		"gl": {"luns", "lun 19 out 2026"},
+		"pt": {"segunda-feira", "seg 19 out 2026"},
+	}
+	for lang, want := range cases {
+		l := New(lang)
+		if got := l.Weekday(d); got != want[0] {
+			t.Errorf("%s Weekday = %q, want %q", lang, got, want[0])
+		}
+		if got := l.Date(d); got != want[1] {
+			t.Errorf("%s Date = %q, want %q", lang, got, want[1])
+		}
+	}
+	if got := New("es").DateTime(d); got != "lun 19 oct 2026 09:05:00 UTC" {
+		t.Errorf("DateTime = %q", got)
+	}
+}
+
+func TestText(t *testing.T) {
+	cases := []struct{ lang, key, want string }{
+		{"es", "Light rain", "Lluvia ligera"},
+		{"gl", "Clear", "Despexado"},
+		{"en", "Light rain", "Light rain"},
+		{"es", "Not in the catalog", "Not in the catalog"},
+		{"es", "", ""},
+	}
+	for _, c := range cases {
+		if got := New(c.lang).Text(c.key); got != c.want {
+			t.Errorf("%s Text(%q) = %q, want %q", c.lang, c.key, got, c.want)
+		}
+	}
+}
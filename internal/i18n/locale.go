@@ -0,0 +1,138 @@
+// Package i18n traduce los textos de la interfaz y formatea fechas y números
+// según el idioma elegido con --lang.
+package i18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// DefaultLang es el idioma usado cuando no se indica ninguno
+const DefaultLang = "es"
+
+// Idiomas con catálogo. El primero es el de reserva del matcher: las claves
+// del catálogo están en inglés, así que un idioma desconocido las muestra tal cual.
+var supported = []language.Tag{
+	language.English,
+	language.Spanish,
+	language.French,
+	language.MustParse("gl"),
+	language.Portuguese,
+}
+
+var matcher = language.NewMatcher(supported)
+
+// Locale agrupa el catálogo y los nombres de fecha de un idioma
+type Locale struct {
+	lang    string
+	printer *message.Printer
+	names   dateNames
+}
+
+// New devuelve el Locale más parecido a lang ("es", "pt-BR", "es_ES.UTF-8"...).
+// Los idiomas sin catálogo caen en inglés.
+func New(lang string) *Locale {
+	if lang == "" {
+		lang = DefaultLang
+	}
+	// Acepta también el formato de LANG/LC_ALL
+	lang, _, _ = strings.Cut(lang, ".")
+	lang = strings.ReplaceAll(lang, "_", "-")
+
+	tag := language.English
+	if t, err := language.Parse(lang); err == nil {
+		_, idx, conf := matcher.Match(t)
+		if conf != language.No {
+			tag = supported[idx]
+		}
+	}
+	base, _ := tag.Base()
+	return &Locale{
+		lang:    base.String(),
+		printer: message.NewPrinter(tag, message.Catalog(builder)),
+		names:   names[base.String()],
+	}
+}
+
+// Default devuelve el Locale de DefaultLang
+func Default() *Locale { return New(DefaultLang) }
+
+// Supported devuelve los códigos de idioma con catálogo
+func Supported() []string {
+	out := make([]string, len(supported))
+	for i, t := range supported {
+		b, _ := t.Base()
+		out[i] = b.String()
+	}
+	return out
+}
+
+// Lang devuelve el código ISO 639-1 del idioma efectivo
+func (l *Locale) Lang() string { return l.lang }
+
+// Sprintf traduce format con el catálogo y formatea los argumentos con los
+// separadores del idioma. Las cifras por debajo de 10000 no llevan separador
+// de miles, como es habitual en las lecturas meteorológicas (1023 hPa).
+func (l *Locale) Sprintf(format string, a ...any) string {
+	for i, v := range a {
+		a[i] = plainNumber(v)
+	}
+	return l.printer.Sprintf(format, a...)
+}
+
+// Text traduce un texto fijo del catálogo, sin verbos de formato; los que no
+// están en el catálogo se devuelven tal cual
+func (l *Locale) Text(key string) string {
+	if key == "" {
+		return ""
+	}
+	return l.printer.Sprintf(key)
+}
+
+// plainNumber envuelve los números pequeños para que se impriman sin
+// separador de miles pero con el separador decimal del idioma
+func plainNumber(v any) any {
+	var f float64
+	switch n := v.(type) {
+	case float64:
+		f = n
+	case float32:
+		f = float64(n)
+	case int:
+		f = float64(n)
+	case int64:
+		f = float64(n)
+	default:
+		return v
+	}
+	if math.Abs(f) >= 10000 {
+		return v
+	}
+	return number.Decimal(v, number.NoSeparator())
+}
+
+// Weekday devuelve el nombre completo del día de la semana de t
+func (l *Locale) Weekday(t time.Time) string {
+	return l.names.weekdays[t.Weekday()]
+}
+
+// ShortDate formatea t como "lun 15 sep"
+func (l *Locale) ShortDate(t time.Time) string {
+	return l.names.shortWeekdays[t.Weekday()] + " " + t.Format("02") + " " + l.names.shortMonths[t.Month()-1]
+}
+
+// Date formatea t como "lun 15 sep 2025"
+func (l *Locale) Date(t time.Time) string {
+	return l.ShortDate(t) + " " + strconv.Itoa(t.Year())
+}
+
+// DateTime formatea t como "lun 15 sep 2025 09:11:00 CEST"
+func (l *Locale) DateTime(t time.Time) string {
+	return l.Date(t) + " " + t.Format("15:04:05 MST")
+}
@@ -0,0 +1,231 @@
+// Package icons elige el icono de cada condición meteorológica a partir de su
+// código numérico (numeración de WeatherAPI) y de si es de día o de noche.
+package icons
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind agrupa los códigos de condición que comparten icono
+type Kind int
+
+const (
+	Unknown Kind = iota
+	Clear
+	PartlyCloudy
+	Cloudy
+	Overcast
+	Fog
+	Drizzle
+	Rain
+	HeavyRain
+	Showers
+	Sleet
+	Snow
+	Thunder
+)
+
+var kindNames = map[Kind]string{
+	Unknown:      "unknown",
+	Clear:        "clear",
+	PartlyCloudy: "partly-cloudy",
+	Cloudy:       "cloudy",
+	Overcast:     "overcast",
+	Fog:          "fog",
+	Drizzle:      "drizzle",
+	Rain:         "rain",
+	HeavyRain:    "heavy-rain",
+	Showers:      "showers",
+	Sleet:        "sleet",
+	Snow:         "snow",
+	Thunder:      "thunder",
+}
+
+func (k Kind) String() string { return kindNames[k] }
+
+// codeKinds clasifica los códigos de condición de WeatherAPI
+var codeKinds = map[int]Kind{
+	1000: Clear,
+	1003: PartlyCloudy,
+	1006: Cloudy,
+	1009: Overcast,
+	1030: Fog, 1135: Fog, 1147: Fog,
+	1063: Showers, 1180: Showers, 1240: Showers, 1243: Showers, 1246: Showers,
+	1150: Drizzle, 1153: Drizzle, 1072: Drizzle, 1168: Drizzle, 1171: Drizzle,
+	1183: Rain, 1186: Rain, 1189: Rain, 1198: Rain,
+	1192: HeavyRain, 1195: HeavyRain, 1201: HeavyRain,
+	1069: Sleet, 1204: Sleet, 1207: Sleet, 1237: Sleet, 1249: Sleet, 1252: Sleet, 1261: Sleet, 1264: Sleet,
+	1066: Snow, 1114: Snow, 1117: Snow, 1210: Snow, 1213: Snow, 1216: Snow, 1219: Snow, 1222: Snow, 1225: Snow, 1255: Snow, 1258: Snow,
+	1087: Thunder, 1273: Thunder, 1276: Thunder, 1279: Thunder, 1282: Thunder,
+}
+
+// KindOf devuelve el grupo de un código de condición
+func KindOf(code int) Kind {
+	return codeKinds[code]
+}
+
+// Glyph es el icono de un grupo de día y de noche. Night vacío usa Day.
+type Glyph struct {
+	Day   string `json:"day"`
+	Night string `json:"night,omitempty"`
+}
+
+// Set es un juego de iconos. Los códigos concretos de codes tienen prioridad
+// sobre los grupos de kinds.
+type Set struct {
+	Name string
+	// Decorations indica si junto a las etiquetas (viento, humedad...) se
+	// muestran también los emojis decorativos
+	Decorations bool
+
+	kinds map[Kind]Glyph
+	codes map[int]Glyph
+}
+
+// Condition devuelve el icono para un código de condición y momento del día
+func (s *Set) Condition(code int, isDay bool) string {
+	g, ok := s.codes[code]
+	if !ok {
+		g, ok = s.kinds[KindOf(code)]
+	}
+	if !ok {
+		g = s.kinds[Unknown]
+	}
+	if !isDay && g.Night != "" {
+		return g.Night
+	}
+	return g.Day
+}
+
+var (
+	// Emoji usa emojis Unicode
+	Emoji = &Set{Name: "emoji", Decorations: true, kinds: map[Kind]Glyph{
+		Unknown:      {Day: "🌡️"},
+		Clear:        {Day: "☀️", Night: "🌙"},
+		PartlyCloudy: {Day: "⛅️", Night: "☁️"},
+		Cloudy:       {Day: "☁️"},
+		Overcast:     {Day: "☁️"},
+		Fog:          {Day: "🌫️"},
+		Drizzle:      {Day: "🌦️", Night: "🌧️"},
+		Rain:         {Day: "🌧️"},
+		HeavyRain:    {Day: "🌧️"},
+		Showers:      {Day: "🌦️", Night: "🌧️"},
+		Sleet:        {Day: "🌨️"},
+		Snow:         {Day: "❄️"},
+		Thunder:      {Day: "⛈️"},
+	}}
+
+	// NerdFont usa los glifos "weather" de Nerd Fonts (nf-weather-*)
+	NerdFont = &Set{Name: "nerd", Decorations: true, kinds: map[Kind]Glyph{
+		Unknown:      {Day: "\ue374"},                  // na
+		Clear:        {Day: "\ue30d", Night: "\ue32b"}, // day_sunny / night_clear
+		PartlyCloudy: {Day: "\ue302", Night: "\ue37e"}, // day_cloudy / night_alt_cloudy
+		Cloudy:       {Day: "\ue312"},                  // cloudy
+		Overcast:     {Day: "\ue33d"},                  // cloud
+		Fog:          {Day: "\ue313"},                  // fog
+		Drizzle:      {Day: "\ue30b", Night: "\ue328"}, // day_sprinkle / night_alt_sprinkle
+		Rain:         {Day: "\ue318"},                  // rain
+		HeavyRain:    {Day: "\ue318"},                  // rain
+		Showers:      {Day: "\ue309", Night: "\ue326"}, // day_showers / night_alt_showers
+		Sleet:        {Day: "\ue3ad"},                  // sleet
+		Snow:         {Day: "\ue31a"},                  // snow
+		Thunder:      {Day: "\ue31d"},                  // thunderstorm
+	}}
+
+	// ASCII usa etiquetas de texto para terminales sin Unicode
+	ASCII = &Set{Name: "ascii", kinds: map[Kind]Glyph{
+		Unknown:      {Day: "[?]"},
+		Clear:        {Day: "[sun]", Night: "[moon]"},
+		PartlyCloudy: {Day: "[part]"},
+		Cloudy:       {Day: "[cloud]"},
+		Overcast:     {Day: "[ovc]"},
+		Fog:          {Day: "[fog]"},
+		Drizzle:      {Day: "[drzl]"},
+		Rain:         {Day: "[rain]"},
+		HeavyRain:    {Day: "[RAIN]"},
+		Showers:      {Day: "[shwr]"},
+		Sleet:        {Day: "[sleet]"},
+		Snow:         {Day: "[snow]"},
+		Thunder:      {Day: "[storm]"},
+	}}
+)
+
+var sets = map[string]*Set{
+	Emoji.Name:    Emoji,
+	NerdFont.Name: NerdFont,
+	ASCII.Name:    ASCII,
+}
+
+// Names devuelve los nombres de los juegos incluidos, ordenados
+func Names() []string {
+	out := make([]string, 0, len(sets))
+	for name := range sets {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Get devuelve el juego con ese nombre. El nombre vacío es Emoji.
+func Get(name string) (*Set, error) {
+	if name == "" {
+		return Emoji, nil
+	}
+	s, ok := sets[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown icon set %q (want one of %s)", name, strings.Join(Names(), ", "))
+	}
+	return s, nil
+}
+
+// Load lee un fichero JSON de iconos de usuario que sobrescribe a base. Las
+// claves son códigos de condición ("1183") o nombres de grupo ("rain"):
+//
+//	{"rain": {"day": "R"}, "1000": {"day": "S", "night": "M"}}
+func Load(path string, base *Set) (*Set, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]Glyph
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("icons file %s: %w", path, err)
+	}
+
+	s := &Set{Name: path, Decorations: base.Decorations, kinds: map[Kind]Glyph{}, codes: map[int]Glyph{}}
+	for k, g := range base.kinds {
+		s.kinds[k] = g
+	}
+	for k, g := range base.codes {
+		s.codes[k] = g
+	}
+	for key, g := range raw {
+		if g.Day == "" {
+			return nil, fmt.Errorf("icons file %s: %q has no day icon", path, key)
+		}
+		if code, err := strconv.Atoi(key); err == nil {
+			s.codes[code] = g
+			continue
+		}
+		kind, ok := kindByName(key)
+		if !ok {
+			return nil, fmt.Errorf("icons file %s: unknown condition %q", path, key)
+		}
+		s.kinds[kind] = g
+	}
+	return s, nil
+}
+
+func kindByName(name string) (Kind, bool) {
+	for k, n := range kindNames {
+		if n == name {
+			return k, true
+		}
+	}
+	return Unknown, false
+}
@@ -0,0 +1,91 @@
+package icons
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCondition_DayNight(t *testing.T) {
+	cases := []struct {
+		set   *Set
+		code  int
+		isDay bool
+		want  string
+	}{
+		{Emoji, 1000, true, "☀️"},
+		{Emoji, 1000, false, "🌙"},
+		{Emoji, 1195, false, "🌧️"}, // sin variante nocturna
+		{Emoji, 1276, true, "⛈️"},
+		{Emoji, 4242, true, "🌡️"}, // código desconocido
+		{ASCII, 1225, true, "[snow]"},
+		{ASCII, 1003, false, "[part]"},
+		{NerdFont, 1000, false, "\ue32b"},
+	}
+	for _, c := range cases {
+		if got := c.set.Condition(c.code, c.isDay); got != c.want {
+			t.Errorf("%s.Condition(%d, %v) = %q, want %q", c.set.Name, c.code, c.isDay, got, c.want)
+		}
+	}
+}
+
+// Todos los códigos de WeatherAPI deben tener grupo
+func TestKindOf_AllCodes(t *testing.T) {
+	for _, code := range []int{1000, 1003, 1006, 1009, 1030, 1063, 1066, 1069, 1072, 1087, 1114, 1117, 1135, 1147,
+		1150, 1153, 1168, 1171, 1180, 1183, 1186, 1189, 1192, 1195, 1198, 1201, 1204, 1207, 1210, 1213, 1216, 1219,
+		1222, 1225, 1237, 1240, 1243, 1246, 1249, 1252, 1255, 1258, 1261, 1264, 1273, 1276, 1279, 1282} {
+		if KindOf(code) == Unknown {
+			t.Errorf("code %d has no kind", code)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	if s, err := Get(""); err != nil || s != Emoji {
+		t.Fatalf("Get(\"\") = %v, %v", s, err)
+	}
+	if s, err := Get("ASCII"); err != nil || s != ASCII {
+		t.Fatalf("Get(ASCII) = %v, %v", s, err)
+	}
+	if _, err := Get("wingdings"); err == nil {
+		t.Fatal("expected error for unknown set")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "icons.json")
+	data := `{"rain": {"day": "R"}, "1000": {"day": "S", "night": "M"}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path, ASCII)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		code  int
+		isDay bool
+		want  string
+	}{
+		{1189, true, "R"},
+		{1000, true, "S"},
+		{1000, false, "M"},
+		{1225, true, "[snow]"}, // heredado de la base
+	} {
+		if got := s.Condition(c.code, c.isDay); got != c.want {
+			t.Errorf("Condition(%d, %v) = %q, want %q", c.code, c.isDay, got, c.want)
+		}
+	}
+	if ASCII.Condition(1189, true) != "[rain]" {
+		t.Error("Load must not modify the base set")
+	}
+
+	for _, bad := range []string{`{"drizzel": {"day": "D"}}`, `{"rain": {"night": "N"}}`, `[1]`} {
+		if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path, Emoji); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
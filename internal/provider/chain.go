@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/api/weatherapi"
+	"mruiz/cliWeather/internal/weather"
+	"net"
+	"strings"
+	"time"
+)
+
+// Chain prueba una lista ordenada de proveedores y pasa al siguiente cuando
+// el actual falla por un motivo transitorio (ver ShouldFailover). El
+// Forecast devuelto indica en Provider quién sirvió los datos.
+type Chain struct {
+	providers []weather.Provider
+	timeout   time.Duration // por intento; 0 = sin límite propio
+
+	// OnFailover, si no es nil, se llama cada vez que se descarta un proveedor
+	OnFailover func(p weather.Provider, err error)
+}
+
+func NewChain(timeout time.Duration, providers ...weather.Provider) *Chain {
+	return &Chain{providers: providers, timeout: timeout}
+}
+
+func (c *Chain) Name() string {
+	names := make([]string, len(c.providers))
+	for i, p := range c.providers {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, ",")
+}
+
+// Len devuelve el número de proveedores de la cadena
+func (c *Chain) Len() int { return len(c.providers) }
+
+func (c *Chain) Forecast(ctx context.Context, req weather.Request) (*weather.Forecast, error) {
+	return try(ctx, c, func(ctx context.Context, p weather.Provider) (*weather.Forecast, error) {
+		return p.Forecast(ctx, req)
+	})
+}
+
+func (c *Chain) Current(ctx context.Context, req weather.Request) (*weather.Forecast, error) {
+	return try(ctx, c, func(ctx context.Context, p weather.Provider) (*weather.Forecast, error) {
+		return weather.FetchCurrent(ctx, p, req)
+	})
+}
+
+func (c *Chain) Search(ctx context.Context, text string) ([]weather.Location, error) {
+	return try(ctx, c, func(ctx context.Context, p weather.Provider) ([]weather.Location, error) {
+		return weather.Search(ctx, p, text)
+	})
+}
+
+func (c *Chain) History(ctx context.Context, query string, date time.Time) (*weather.Forecast, error) {
+	return try(ctx, c, func(ctx context.Context, p weather.Provider) (*weather.Forecast, error) {
+		hp, ok := p.(weather.HistoryProvider)
+		if !ok {
+			return nil, fmt.Errorf("%s: history: %w", p.Name(), weather.ErrNotSupported)
+		}
+		return hp.History(ctx, query, date)
+	})
+}
+
+// try ejecuta call sobre cada proveedor hasta que uno responda
+func try[T any](ctx context.Context, c *Chain, call func(context.Context, weather.Provider) (T, error)) (T, error) {
+	var zero T
+	var errs []error
+	for i, p := range c.providers {
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if c.timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, c.timeout)
+		}
+		res, err := call(attemptCtx, p)
+		cancel()
+		if err == nil {
+			return res, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+		last := i == len(c.providers)-1
+		// Si el contexto del llamador se ha cancelado no tiene sentido seguir
+		if last || ctx.Err() != nil || !ShouldFailover(err) {
+			break
+		}
+		if c.OnFailover != nil {
+			c.OnFailover(p, err)
+		}
+	}
+	if len(errs) == 1 {
+		return zero, errors.Unwrap(errs[0])
+	}
+	return zero, fmt.Errorf("all providers failed: %w", errors.Join(errs...))
+}
+
+// ShouldFailover indica si un error justifica probar el siguiente proveedor:
+// timeouts y fallos de red, errores 5xx, cuota agotada y respuestas que no
+// se pueden decodificar, además de las operaciones que el proveedor no
+// soporta. Los errores de configuración o de localización no cambian por
+// cambiar de proveedor, así que se devuelven tal cual.
+func ShouldFailover(err error) bool {
+	var (
+		netErr    net.Error
+		httpErr   *weather.HTTPError
+		apiErr    *weatherapi.APIError
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.Is(err, weather.ErrNotSupported):
+		return true
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return true
+	case errors.As(err, &httpErr):
+		return httpErr.Status >= 500 || httpErr.Status == 429
+	case errors.Is(err, weatherapi.ErrQuotaExceeded), errors.Is(err, weatherapi.ErrInternal):
+		return true
+	case errors.As(err, &apiErr):
+		return apiErr.Status >= 500 || apiErr.Status == 429
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mruiz/cliWeather/internal/api/weatherapi"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/weather"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeProvider devuelve siempre el mismo error (o una previsión si err es nil)
+type fakeProvider struct {
+	name  string
+	err   error
+	calls int
+}
+
+func (f *fakeProvider) Name() string { return f.name }
+
+func (f *fakeProvider) Forecast(ctx context.Context, req weather.Request) (*weather.Forecast, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &weather.Forecast{Provider: f.name}, nil
+}
+
+func TestChain_FailsOverOnTransientErrors(t *testing.T) {
+	transient := []error{
+		&weatherapi.APIError{Status: 403, Code: 2007},
+		&weather.HTTPError{Provider: "x", Status: 503},
+		context.DeadlineExceeded,
+		&json.SyntaxError{},
+	}
+	for _, err := range transient {
+		first := &fakeProvider{name: "first", err: err}
+		second := &fakeProvider{name: "second"}
+
+		var reported []string
+		chain := NewChain(time.Second, first, second)
+		chain.OnFailover = func(p weather.Provider, err error) { reported = append(reported, p.Name()) }
+
+		f, gotErr := chain.Forecast(context.Background(), weather.Request{Query: "Vigo", Days: 1})
+		if gotErr != nil {
+			t.Fatalf("%v: unexpected error %v", err, gotErr)
+		}
+		if f.Provider != "second" {
+			t.Fatalf("%v: expected data from second provider, got %q", err, f.Provider)
+		}
+		if len(reported) != 1 || reported[0] != "first" {
+			t.Fatalf("%v: expected failover to be reported, got %v", err, reported)
+		}
+	}
+}
+
+func TestChain_StopsOnPermanentErrors(t *testing.T) {
+	notFound := &weatherapi.APIError{Status: 400, Code: 1006}
+	first := &fakeProvider{name: "first", err: notFound}
+	second := &fakeProvider{name: "second"}
+
+	_, err := NewChain(time.Second, first, second).Forecast(context.Background(), weather.Request{Query: "Nowhere"})
+	if !errors.Is(err, weather.ErrLocationNotFound) {
+		t.Fatalf("expected location error, got %v", err)
+	}
+	if second.calls != 0 {
+		t.Fatal("second provider must not be called on permanent errors")
+	}
+}
+
+func TestChain_AllFail(t *testing.T) {
+	first := &fakeProvider{name: "first", err: &weather.HTTPError{Provider: "first", Status: 500}}
+	second := &fakeProvider{name: "second", err: &weatherapi.APIError{Status: 403, Code: 2007}}
+
+	_, err := NewChain(0, first, second).Forecast(context.Background(), weather.Request{Query: "Vigo"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !errors.Is(err, weatherapi.ErrQuotaExceeded) {
+		t.Fatalf("expected joined errors to keep sentinels, got %v", err)
+	}
+}
+
+func TestNewList(t *testing.T) {
+	p, err := NewList("openmeteo, metno", Options{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name() != "openmeteo,metno" {
+		t.Fatalf("unexpected chain name %q", p.Name())
+	}
+	if _, err := NewList("openmeteo,nope", Options{}); err == nil {
+		t.Fatal("expected error for unknown provider")
+	}
+}
+
+func TestNewList_SkipsWeatherAPIWithoutKey(t *testing.T) {
+	var skipped []string
+	opt := Options{OnSkip: func(name string, err error) {
+		if !errors.Is(err, weatherapi.ErrMissingKey) {
+			t.Errorf("OnSkip(%s) with %v, want ErrMissingKey", name, err)
+		}
+		skipped = append(skipped, name)
+	}}
+	p, err := NewList("weatherapi,openmeteo", opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name() != "openmeteo" || len(skipped) != 1 || skipped[0] != "weatherapi" {
+		t.Fatalf("got %q skipping %q, want openmeteo skipping weatherapi", p.Name(), skipped)
+	}
+
+	skipped = nil
+	if _, err := NewList("weatherapi", opt); !errors.Is(err, weatherapi.ErrMissingKey) {
+		t.Fatalf("weatherapi alone without key: %v, want ErrMissingKey", err)
+	}
+	if len(skipped) != 0 {
+		t.Fatalf("no provider left but OnSkip was called for %q", skipped)
+	}
+
+	opt.APIKey = "key"
+	if p, err := NewList("weatherapi,openmeteo", opt); err != nil || p.Name() != "weatherapi,openmeteo" {
+		t.Fatalf("with key: %v, %v", p, err)
+	}
+}
+
+// config valida los nombres con su propia lista para no importar este paquete
+func TestNames_MatchConfig(t *testing.T) {
+	if !slices.Equal(Names(), config.Providers) {
+		t.Errorf("registered providers %v, config.Providers %v", Names(), config.Providers)
+	}
+}
@@ -0,0 +1,107 @@
+// Package provider construye los backends de weather.Provider por nombre
+// a partir de la configuración común.
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"mruiz/cliWeather/internal/api/metno"
+	"mruiz/cliWeather/internal/api/openmeteo"
+	"mruiz/cliWeather/internal/api/weatherapi"
+	"mruiz/cliWeather/internal/cache"
+	"mruiz/cliWeather/internal/weather"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Options son los ajustes compartidos por todos los proveedores
+type Options struct {
+	APIKey  string // solo WeatherAPI
+	Lang    string
+	Timeout time.Duration
+	Cache   *cache.Cache // nil desactiva la caché
+
+	// OnSkip, si no es nil, se llama cuando NewList deja fuera de una lista
+	// un proveedor que no se puede usar (weatherapi sin clave)
+	OnSkip func(name string, err error)
+}
+
+type factory func(opt Options) (weather.Provider, error)
+
+var factories = map[string]factory{
+	"weatherapi": func(opt Options) (weather.Provider, error) {
+		if opt.APIKey == "" {
+			return nil, weatherapi.ErrMissingKey
+		}
+		c := weatherapi.NewClient(opt.APIKey, opt.Lang, opt.Timeout).WithCache(opt.Cache)
+		return weatherapi.NewProvider(c), nil
+	},
+	"openmeteo": func(opt Options) (weather.Provider, error) {
+		return openmeteo.NewClient(opt.Lang, opt.Timeout).WithCache(opt.Cache), nil
+	},
+	"metno": func(opt Options) (weather.Provider, error) {
+		geo := openmeteo.NewClient(opt.Lang, opt.Timeout).WithCache(opt.Cache)
+		return metno.NewClient(geo, opt.Lang, opt.Timeout).WithCache(opt.Cache), nil
+	},
+}
+
+// New devuelve el proveedor registrado con ese nombre
+func New(name string, opt Options) (weather.Provider, error) {
+	f, ok := factories[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return f(opt)
+}
+
+// NewList construye el proveedor para una lista separada por comas
+// ("weatherapi,openmeteo"). Con un solo nombre devuelve ese proveedor; con
+// varios, una Chain que aplica el timeout de opt a cada intento. En una lista
+// weatherapi sin clave se salta (avisando con opt.OnSkip) y solo es un error
+// si no queda ningún otro proveedor.
+func NewList(spec string, opt Options) (weather.Provider, error) {
+	var providers []weather.Provider
+	var skipped []string
+	var skipErr error
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		p, err := New(name, opt)
+		if errors.Is(err, weatherapi.ErrMissingKey) {
+			skipped, skipErr = append(skipped, name), err
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+	if len(providers) == 0 && skipErr != nil {
+		return nil, skipErr
+	}
+	if opt.OnSkip != nil {
+		for _, name := range skipped {
+			opt.OnSkip(name, skipErr)
+		}
+	}
+	switch len(providers) {
+	case 0:
+		return nil, fmt.Errorf("no provider given (available: %s)", strings.Join(Names(), ", "))
+	case 1:
+		return providers[0], nil
+	}
+	return NewChain(opt.Timeout, providers...), nil
+}
+
+// Names lista los proveedores disponibles, ordenados
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for n := range factories {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,124 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+	"time"
+)
+
+// ======= Avisos =======
+
+// severityColor colorea un aviso según su severidad (escala CAP)
+func severityColor(th theme, severity string) func(string) string {
+	switch strings.ToLower(severity) {
+	case "extreme", "severe":
+		return func(s string) string { return th.bold(th.hot(s)) }
+	case "moderate":
+		return th.warn
+	default:
+		return th.value
+	}
+}
+
+// RenderAlerts muestra los avisos oficiales activos. No escribe nada si
+// no hay avisos.
+func RenderAlerts(f *weather.Forecast, out io.Writer, opt Options) {
+	if len(f.Alerts) == 0 {
+		return
+	}
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+
+	_, _ = fmt.Fprintf(out, "\n%s%s\n", em(opt.decorate(), "⚠️"), th.bold(th.warn(l.Sprintf("ACTIVE ALERTS (%d)", len(f.Alerts)))))
+	for _, a := range f.Alerts {
+		color := severityColor(th, a.Severity)
+		title := a.Headline
+		if title == "" {
+			title = a.Event
+		}
+		severity := a.Severity
+		if severity == "" {
+			severity = "Unknown"
+		}
+		_, _ = fmt.Fprintf(out, "  %s %s\n", color("["+strings.ToUpper(severity)+"]"), color(title))
+
+		if a.Areas != "" {
+			_, _ = fmt.Fprintf(out, "    %s %s\n", th.label(l.Sprintf("area:")), th.value(a.Areas))
+		}
+		if !a.Effective.IsZero() || !a.Expires.IsZero() {
+			_, _ = fmt.Fprintf(out, "    %s %s %s %s\n",
+				th.label(l.Sprintf("from")), th.value(fmtAlertTime(l, a.Effective)),
+				th.label(l.Sprintf("until")), th.value(fmtAlertTime(l, a.Expires)),
+			)
+		}
+		if a.Instruction != "" {
+			_, _ = fmt.Fprintf(out, "    %s\n", th.dim(strings.TrimSpace(a.Instruction)))
+		}
+	}
+}
+
+func fmtAlertTime(l *i18n.Locale, t time.Time) string {
+	if t.IsZero() {
+		return "?"
+	}
+	t = t.Local()
+	return l.ShortDate(t) + " " + t.Format("15:04")
+}
+
+// ======= Calidad del aire =======
+
+// epaLabels son las claves del catálogo para cada índice US-EPA
+var epaLabels = map[int]string{
+	1: "Good",
+	2: "Moderate",
+	3: "Unhealthy for sensitive groups",
+	4: "Unhealthy",
+	5: "Very unhealthy",
+	6: "Hazardous",
+}
+
+// epaColor colorea según el índice US-EPA
+func epaColor(th theme, idx int) func(string) string {
+	switch {
+	case idx <= 1:
+		return th.ok
+	case idx == 2:
+		return th.value
+	case idx == 3:
+		return th.warn
+	default:
+		return th.hot
+	}
+}
+
+// RenderAirQuality muestra los índices y contaminantes. No escribe nada
+// si aq es nil.
+func RenderAirQuality(aq *weather.AirQuality, out io.Writer, opt Options) {
+	if aq == nil {
+		return
+	}
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+	color := epaColor(th, aq.USEPAIndex)
+
+	label, ok := epaLabels[aq.USEPAIndex]
+	if !ok {
+		label = "Unknown"
+	}
+	_, _ = fmt.Fprintf(out, "%s%s %s %s\n",
+		em(opt.decorate(), "🌬️"), th.label(l.Sprintf("Air quality:")), color(l.Sprintf(label)),
+		th.dim(fmt.Sprintf("(US-EPA %d, DEFRA %d)", aq.USEPAIndex, aq.GBDefraIndex)),
+	)
+	_, _ = fmt.Fprintf(out, "  %s %s  %s %s  %s %s  %s %s  %s %s  %s %s  %s\n",
+		th.label("PM2.5"), th.value(l.Sprintf("%.1f", aq.PM25)),
+		th.label("PM10"), th.value(l.Sprintf("%.1f", aq.PM10)),
+		th.label("O3"), th.value(l.Sprintf("%.1f", aq.O3)),
+		th.label("NO2"), th.value(l.Sprintf("%.1f", aq.NO2)),
+		th.label("SO2"), th.value(l.Sprintf("%.1f", aq.SO2)),
+		th.label("CO"), th.value(l.Sprintf("%.1f", aq.CO)),
+		th.dim("μg/m³"),
+	)
+}
@@ -0,0 +1,53 @@
+package render
+
+import (
+	"bytes"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderAlerts(t *testing.T) {
+	now := time.Now()
+	w := weather.Forecast{
+		Alerts: []weather.Alert{
+			{Headline: "Aviso naranja por viento", Severity: "Severe", Areas: "Rías Baixas", Effective: now, Expires: now.Add(6 * time.Hour)},
+			{Event: "Lluvia", Severity: "Moderate"},
+		},
+	}
+
+	var buf bytes.Buffer
+	RenderAlerts(&w, &buf, Options{})
+	out := buf.String()
+	for _, want := range []string{"AVISOS ACTIVOS (2)", "[SEVERE] Aviso naranja por viento", "zona: Rías Baixas", "[MODERATE] Lluvia"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	// Con color, los avisos graves van en rojo (31) y los moderados en amarillo (33)
+	buf.Reset()
+	RenderAlerts(&w, &buf, Options{Color: true})
+	out = buf.String()
+	if !strings.Contains(out, "\x1b[31m[SEVERE]") || !strings.Contains(out, "\x1b[33m[MODERATE]") {
+		t.Fatalf("expected severity colours, got:\n%q", out)
+	}
+
+	buf.Reset()
+	RenderAlerts(&weather.Forecast{}, &buf, Options{})
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output without alerts, got %q", buf.String())
+	}
+}
+
+func TestRenderAirQuality(t *testing.T) {
+	var buf bytes.Buffer
+	RenderAirQuality(&weather.AirQuality{PM25: 12.3, PM10: 20, USEPAIndex: 3, GBDefraIndex: 4}, &buf, Options{})
+	out := buf.String()
+	for _, want := range []string{"Dañina para grupos sensibles", "US-EPA 3, DEFRA 4", "PM2.5 12,3"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+	"time"
+)
+
+// ChartKind es el tipo de gráfica de --chart
+type ChartKind string
+
+const (
+	NoChart    ChartKind = ""
+	SparkChart ChartKind = "spark" // una fila de bloques por serie
+	LineChart  ChartKind = "line"  // varias filas de braille por serie
+)
+
+// ParseChartKind valida el valor de --chart
+func ParseChartKind(s string) (ChartKind, error) {
+	switch k := ChartKind(strings.ToLower(strings.TrimSpace(s))); k {
+	case NoChart, SparkChart, LineChart:
+		return k, nil
+	default:
+		return "", fmt.Errorf("unknown chart %q (expected spark or line)", s)
+	}
+}
+
+const (
+	defaultChartWidth = 80
+	lineChartRows     = 4 // filas de texto de cada gráfica de líneas
+)
+
+// Niveles de las sparklines, de menor a mayor
+var (
+	sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+	sparkASCII  = []rune("_.-:=+*#")
+)
+
+// ChartASCII indica si las gráficas deben usar solo ASCII: sin color o sin
+// emojis (incluido --icons ascii) se asume una terminal limitada
+func ChartASCII(opt Options) bool {
+	return !opt.Color || !opt.decorate()
+}
+
+// series es una magnitud a dibujar: valores métricos del modelo, cómo
+// mostrar sus extremos y el color de cada valor
+type series struct {
+	label  string
+	values []float64
+	format func(float64) string
+	color  func(float64) func(string) string
+}
+
+// chartSeries prepara temperatura, probabilidad de lluvia y viento de las
+// horas dadas
+func chartSeries(hours []weather.Hour, opt Options) []series {
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+	u := opt.units()
+	temp := series{label: l.Sprintf("Temp"), format: u.Temp, color: func(v float64) func(string) string { return tempColor(th, v) }}
+	rain := series{label: l.Sprintf("Rain"), format: func(v float64) string { return l.Sprintf("%.0f%%", v) }, color: func(v float64) func(string) string { return percentColor(th, v) }}
+	wind := series{label: l.Sprintf("Wind"), format: u.Wind, color: func(float64) func(string) string { return th.value }}
+	for _, h := range hours {
+		temp.values = append(temp.values, h.TempC)
+		rain.values = append(rain.values, h.ChanceOfRain)
+		wind.values = append(wind.values, h.WindKph)
+	}
+	return []series{temp, rain, wind}
+}
+
+// ChartRows devuelve una sparkline por serie (temperatura, lluvia y viento)
+// de las horas del día idx, o de toda la previsión si idx < 0, con su
+// etiqueta y sus extremos, ocupando como mucho width columnas
+func ChartRows(f *weather.Forecast, idx, width int, opt Options) []string {
+	hours := windowHours(f, idx)
+	if len(hours) == 0 {
+		return nil
+	}
+	all := chartSeries(hours, opt)
+	labelW, rangeW := 0, 0
+	ranges := make([]string, len(all))
+	for i, s := range all {
+		lo, hi := minMax(s.values)
+		ranges[i] = s.format(lo) + " – " + s.format(hi)
+		labelW = max(labelW, len([]rune(s.label)))
+		rangeW = max(rangeW, len([]rune(ranges[i])))
+	}
+	sparkW := max(width-labelW-rangeW-4, 8)
+
+	var lines []string
+	for i, s := range all {
+		lines = append(lines, fmt.Sprintf("%-*s  %s  %s", labelW, s.label, colorSpark(s, sparkW, opt), ranges[i]))
+	}
+	return lines
+}
+
+// colorSpark dibuja la sparkline de s coloreando cada carácter según su valor
+func colorSpark(s series, width int, opt Options) string {
+	spark := Sparkline(s.values, width, ChartASCII(opt))
+	if !opt.Color {
+		return spark
+	}
+	cols := resample(s.values, width)
+	cells := make([]string, 0, width)
+	colors := make([]func(string) string, 0, width)
+	for i, r := range []rune(spark) {
+		cells = append(cells, string(r))
+		colors = append(colors, s.color(cols[i]))
+	}
+	return paintRuns(cells, colors)
+}
+
+// paintRuns colorea cada celda con su color agrupando las consecutivas del
+// mismo color, para no emitir una secuencia ANSI por carácter. Un color nil
+// deja la celda sin colorear.
+func paintRuns(cells []string, colors []func(string) string) string {
+	var b, run strings.Builder
+	key := ""
+	var paint func(string) string
+	flush := func() {
+		if paint != nil {
+			b.WriteString(paint(run.String()))
+		} else {
+			b.WriteString(run.String())
+		}
+		run.Reset()
+	}
+	for i, cell := range cells {
+		k := ""
+		if colors[i] != nil {
+			k = colors[i]("")
+		}
+		if i > 0 && k != key {
+			flush()
+		}
+		key, paint = k, colors[i]
+		run.WriteString(cell)
+	}
+	flush()
+	return b.String()
+}
+
+// RenderCharts dibuja temperatura, probabilidad de lluvia y viento de toda
+// la previsión en el ancho de opt.Width, como sparklines o como gráficas de
+// líneas según opt.Chart, con un eje de días debajo
+func RenderCharts(f *weather.Forecast, out io.Writer, opt Options) {
+	th := makeTheme(opt.Color)
+	width := opt.Width
+	if width <= 0 {
+		width = defaultChartWidth
+	}
+	hours := windowHours(f, -1)
+	if len(hours) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(out)
+	if opt.Chart != LineChart {
+		for _, line := range ChartRows(f, -1, width, opt) {
+			_, _ = fmt.Fprintln(out, "  "+line)
+		}
+		return
+	}
+
+	// Gráficas de líneas: título con extremos, filas con el máximo arriba y
+	// el mínimo abajo en el eje, y los días debajo
+	all := chartSeries(hours, opt)
+	axisW := 0
+	for _, s := range all {
+		lo, hi := minMax(s.values)
+		axisW = max(axisW, len([]rune(s.format(lo))), len([]rune(s.format(hi))))
+	}
+	plotW := max(width-axisW-4, 8)
+	bar := "│"
+	if ChartASCII(opt) {
+		bar = "|"
+	}
+	for _, s := range all {
+		lo, hi := minMax(s.values)
+		_, _ = fmt.Fprintf(out, "  %s\n", th.label(s.label))
+		for r, row := range plotLine(s, plotW, opt) {
+			axis := ""
+			switch r {
+			case 0:
+				axis = s.format(hi)
+			case lineChartRows - 1:
+				axis = s.format(lo)
+			}
+			_, _ = fmt.Fprintf(out, "  %s %s%s\n", th.dim(fmt.Sprintf("%*s", axisW, axis)), th.dim(bar), row)
+		}
+	}
+	_, _ = fmt.Fprintf(out, "  %*s  %s\n", axisW, "", th.dim(dayAxis(f, hours, plotW, opt)))
+}
+
+// plotLine dibuja s en lineChartRows filas de width columnas. Con Unicode
+// cada celda braille tiene 2×4 puntos; en ASCII un punto por celda.
+func plotLine(s series, width int, opt Options) []string {
+	ascii := ChartASCII(opt)
+	dotsX, dotsY := width*2, lineChartRows*4
+	if ascii {
+		dotsX, dotsY = width, lineChartRows
+	}
+	points := resample(s.values, dotsX)
+	lo, hi := minMax(s.values)
+	ys := make([]int, dotsX)
+	for i, v := range points {
+		if hi > lo {
+			ys[i] = int(math.Round((v - lo) / (hi - lo) * float64(dotsY-1)))
+		}
+	}
+
+	// Rejilla de puntos (y = 0 abajo) uniendo cada punto con el anterior
+	grid := make([][]bool, dotsY)
+	for y := range grid {
+		grid[y] = make([]bool, dotsX)
+	}
+	for x, y := range ys {
+		from, to := y, y
+		if x > 0 {
+			from, to = min(y, ys[x-1]), max(y, ys[x-1])
+		}
+		for yy := from; yy <= to; yy++ {
+			grid[yy][x] = true
+		}
+	}
+
+	cols := resample(s.values, width)
+	rows := make([]string, lineChartRows)
+	for r := range rows {
+		cells := make([]string, width)
+		colors := make([]func(string) string, width)
+		for c := range width {
+			cells[c] = " "
+			if ascii {
+				if grid[lineChartRows-1-r][c] {
+					cells[c] = "*"
+				}
+			} else {
+				cells[c] = brailleCell(grid, r, c)
+			}
+			if cells[c] != " " && opt.Color {
+				colors[c] = s.color(cols[c])
+			}
+		}
+		rows[r] = paintRuns(cells, colors)
+	}
+	return rows
+}
+
+// brailleDots son los bits de cada punto de una celda braille por fila
+// (de arriba abajo) y columna
+var brailleDots = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// brailleCell compone la celda de la fila de texto r y columna c
+func brailleCell(grid [][]bool, r, c int) string {
+	var bits rune
+	top := len(grid) - 1 - r*4
+	for dy := range 4 {
+		for dx := range 2 {
+			if grid[top-dy][c*2+dx] {
+				bits |= brailleDots[dy][dx]
+			}
+		}
+	}
+	if bits == 0 {
+		return " "
+	}
+	return string(0x2800 + bits)
+}
+
+// dayAxis pone el día de la semana bajo la columna en que empieza cada día,
+// si cabe sin pisar al anterior
+func dayAxis(f *weather.Forecast, hours []weather.Hour, width int, opt Options) string {
+	l := opt.locale()
+	tz := locationTZ(f)
+	axis := []rune(strings.Repeat(" ", width))
+	next := 0
+	var last time.Time
+	for i, h := range hours {
+		t := h.Time.In(tz)
+		if i > 0 && t.Day() == last.Day() {
+			continue
+		}
+		last = t
+		col := i * width / len(hours)
+		label := []rune(l.Weekday(t))
+		if col < next || col+len(label) > width {
+			continue
+		}
+		copy(axis[col:], label)
+		next = col + len(label) + 1
+	}
+	return strings.TrimRight(string(axis), " ")
+}
+
+// windowHours devuelve las horas del día idx, o de todos si idx < 0
+func windowHours(f *weather.Forecast, idx int) []weather.Hour {
+	if idx >= 0 {
+		if idx >= len(f.Days) {
+			return nil
+		}
+		return f.Days[idx].Hours
+	}
+	var hours []weather.Hour
+	for _, d := range f.Days {
+		hours = append(hours, d.Hours...)
+	}
+	return hours
+}
+
+// Sparkline dibuja values en width caracteres, un nivel por carácter entre
+// el mínimo y el máximo de la serie. Si hay más valores que ancho se
+// promedian por tramos; si hay menos, cada valor ocupa varias columnas. Con
+// ascii se usan caracteres ASCII en lugar de bloques Unicode.
+func Sparkline(values []float64, width int, ascii bool) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	levels := sparkBlocks
+	if ascii {
+		levels = sparkASCII
+	}
+
+	cols := resample(values, width)
+	lo, hi := minMax(cols)
+	var b strings.Builder
+	for _, v := range cols {
+		i := 0
+		if hi > lo {
+			i = int(math.Round((v - lo) / (hi - lo) * float64(len(levels)-1)))
+		}
+		b.WriteRune(levels[i])
+	}
+	return b.String()
+}
+
+// resample ajusta values a width columnas: promedio de cada tramo al
+// reducir y repetición al ampliar
+func resample(values []float64, width int) []float64 {
+	out := make([]float64, width)
+	n := len(values)
+	for c := range out {
+		from := c * n / width
+		to := max((c+1)*n/width, from+1)
+		var sum float64
+		for _, v := range values[from:to] {
+			sum += v
+		}
+		out[c] = sum / float64(to-from)
+	}
+	return out
+}
+
+func minMax(values []float64) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	return lo, hi
+}
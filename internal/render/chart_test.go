@@ -0,0 +1,88 @@
+package render
+
+import (
+	"bytes"
+	"mruiz/cliWeather/internal/i18n"
+	"strings"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	cases := []struct {
+		values []float64
+		width  int
+		ascii  bool
+		want   string
+	}{
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, 8, false, "▁▂▃▄▅▆▇█"},
+		{[]float64{0, 7}, 4, false, "▁▁██"},
+		{[]float64{0, 0, 7, 7}, 2, true, "_#"},
+		{[]float64{3, 3, 3}, 3, false, "▁▁▁"},
+		{nil, 5, false, ""},
+	}
+	for _, c := range cases {
+		if got := Sparkline(c.values, c.width, c.ascii); got != c.want {
+			t.Errorf("Sparkline(%v, %d) = %q, want %q", c.values, c.width, got, c.want)
+		}
+	}
+}
+
+func TestParseChartKind(t *testing.T) {
+	for in, want := range map[string]ChartKind{"": NoChart, "spark": SparkChart, "LINE": LineChart} {
+		if got, err := ParseChartKind(in); err != nil || got != want {
+			t.Errorf("ParseChartKind(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseChartKind("bars"); err == nil {
+		t.Error("expected error for bars")
+	}
+}
+
+func TestRenderCharts_Spark(t *testing.T) {
+	var buf bytes.Buffer
+	RenderCharts(sampleForecast(), &buf, Options{Chart: SparkChart, Width: 40, Locale: i18n.New("en")})
+	want := "\n" +
+		"  Temp  ________#######  14°C – 20°C\n" +
+		"  Rain  ________#######  20% – 87%\n" +
+		"  Wind  ________#######  10 km/h – 18 km/h\n"
+	if buf.String() != want {
+		t.Errorf("spark charts =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestRenderCharts_Line(t *testing.T) {
+	f := sampleForecast()
+	opt := Options{Chart: LineChart, Width: 30, Locale: i18n.New("en")}
+
+	// Sin color ni emojis: ASCII, máximo arriba y mínimo abajo en el eje
+	var buf bytes.Buffer
+	RenderCharts(f, &buf, opt)
+	lines := strings.Split(buf.String(), "\n")
+	if lines[1] != "  Temp" || !strings.HasPrefix(lines[2], "     20°C |") || !strings.HasPrefix(lines[5], "     14°C |***") {
+		t.Errorf("ascii line chart:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "Monday") {
+		t.Errorf("missing day axis:\n%s", buf.String())
+	}
+
+	// Con color y emojis: braille coloreado por tramos, del mismo ancho
+	opt.Color, opt.Emoji = true, true
+	buf.Reset()
+	RenderCharts(f, &buf, opt)
+	if !strings.ContainsRune(buf.String(), '⣀') || strings.Contains(buf.String(), "*") {
+		t.Errorf("braille line chart:\n%s", buf.String())
+	}
+	if n := strings.Count(strings.Split(buf.String(), "\n")[5], "\x1b[97m"); n != 1 {
+		t.Errorf("a run of one color must be painted once, got %d sequences", n)
+	}
+}
+
+func TestRenderDay_ChartOmitsHours(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderDay(sampleForecast(), 0, 1, &buf, Options{Chart: SparkChart}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "Light rain -") {
+		t.Errorf("hour list must be omitted with a chart:\n%s", buf.String())
+	}
+}
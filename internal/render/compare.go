@@ -0,0 +1,376 @@
+package render
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
+	"slices"
+	"strings"
+	"time"
+)
+
+// comfortTempC es la máxima ideal para estar al aire libre: en compare gana
+// la máxima más cercana a ella, no la más alta
+const comfortTempC = 22
+
+// compareMetric es una fila de compare: su nombre en JSON y CSV, su
+// etiqueta, el valor en unidades del modelo y su puntuación (menor es mejor)
+type compareMetric struct {
+	name  string
+	label string
+	value func(weather.Day) float64
+	score func(float64) float64
+}
+
+func lowest(v float64) float64 { return v }
+
+var compareMetrics = []compareMetric{
+	{"max_temp", "Max temp", func(d weather.Day) float64 { return d.MaxTempC }, func(c float64) float64 { return math.Abs(c - comfortTempC) }},
+	{"min_temp", "Min temp", func(d weather.Day) float64 { return d.MinTempC }, func(c float64) float64 { return -c }},
+	{"chance_of_rain", "Rain", func(d weather.Day) float64 { return float64(d.ChanceOfRain) }, lowest},
+	{"max_wind", "Wind", func(d weather.Day) float64 { return d.MaxWindKph }, lowest},
+	{"uv", "UV", func(d weather.Day) float64 { return d.UV }, lowest},
+}
+
+// show formatea v en las unidades de u y con los números de l
+func (m compareMetric) show(u units.System, l *i18n.Locale, v float64) string {
+	switch m.name {
+	case "max_temp", "min_temp":
+		return u.Temp(v)
+	case "chance_of_rain":
+		return l.Sprintf("%.0f%%", v)
+	case "max_wind":
+		return u.Wind(v)
+	default:
+		return l.Sprintf("%.0f", v)
+	}
+}
+
+// convert pasa v a las unidades de u, redondeado para JSON y CSV
+func (m compareMetric) convert(u units.System, v float64) float64 {
+	switch m.name {
+	case "max_temp", "min_temp":
+		return round(u.TempValue(v))
+	case "max_wind":
+		return round(u.WindValue(v))
+	default:
+		return round(v)
+	}
+}
+
+// unit devuelve el símbolo de la unidad de la métrica en u
+func (m compareMetric) unit(u units.System) string {
+	switch m.name {
+	case "max_temp", "min_temp":
+		return u.TempSymbol()
+	case "chance_of_rain":
+		return "%"
+	case "max_wind":
+		return u.WindSymbol()
+	default:
+		return ""
+	}
+}
+
+// Comparison pone lado a lado los mismos días de varias localizaciones
+type Comparison struct {
+	Locations []weather.Location
+	Days      []ComparisonDay
+}
+
+type ComparisonDay struct {
+	Date time.Time
+	Rows []ComparisonRow
+}
+
+// ComparisonRow es una métrica de un día: el valor de cada localización en
+// unidades del modelo (nil si no tiene ese día) y cuáles son las mejores
+type ComparisonRow struct {
+	Metric string
+	Values []*float64
+	Best   []bool
+}
+
+// NewComparison compara los primeros days días de las previsiones, en el
+// orden de fs. Los días se emparejan por fecha, no por posición, porque cada
+// localización puede empezar en otra fecha según su zona horaria; la que no
+// tiene un día queda sin valor. La mejor máxima es la más cercana a 22 °C,
+// la mejor mínima la más alta y en lluvia, viento y UV gana el valor más
+// bajo; los empates cuentan todos como mejores.
+func NewComparison(fs []*weather.Forecast, days int) Comparison {
+	c := Comparison{}
+	byDate := make([]map[string]weather.Day, len(fs))
+	dates := map[string]time.Time{}
+	for j, f := range fs {
+		c.Locations = append(c.Locations, f.Location)
+		byDate[j] = map[string]weather.Day{}
+		for _, d := range f.Days {
+			key := d.Date.Format(time.DateOnly)
+			byDate[j][key] = d
+			if _, ok := dates[key]; !ok {
+				dates[key] = d.Date
+			}
+		}
+	}
+	keys := slices.Sorted(maps.Keys(dates))
+
+	for _, key := range keys[:min(len(keys), days)] {
+		day := ComparisonDay{Date: dates[key]}
+		for _, m := range compareMetrics {
+			row := ComparisonRow{Metric: m.name, Values: make([]*float64, len(fs)), Best: make([]bool, len(fs))}
+			best := math.Inf(1)
+			for j := range fs {
+				d, ok := byDate[j][key]
+				if !ok {
+					continue
+				}
+				v := m.value(d)
+				row.Values[j] = &v
+				best = min(best, m.score(v))
+			}
+			for j, v := range row.Values {
+				row.Best[j] = v != nil && m.score(*v) == best
+			}
+			day.Rows = append(day.Rows, row)
+		}
+		c.Days = append(c.Days, day)
+	}
+	return c
+}
+
+// locationLabels devuelve un nombre distinto para cada localización, para
+// las cabeceras y la columna best. Los nombres repetidos (Santiago de España
+// y de Chile, o un alias y su propia ciudad) se completan con la región o el
+// país y, si aún coinciden, con su número de aparición.
+func locationLabels(locs []weather.Location) []string {
+	labels := make([]string, len(locs))
+	for j, loc := range locs {
+		labels[j] = loc.Name
+	}
+	repeated := func() map[string]int {
+		n := map[string]int{}
+		for _, l := range labels {
+			n[l]++
+		}
+		return n
+	}
+	count := repeated()
+	for j, loc := range locs {
+		if count[labels[j]] < 2 {
+			continue
+		}
+		if detail := cmp.Or(loc.Region, loc.Country); detail != "" {
+			labels[j] += ", " + detail
+		}
+	}
+	count = repeated()
+	seen := map[string]int{}
+	for j, l := range labels {
+		if count[l] > 1 {
+			seen[l]++
+			labels[j] = fmt.Sprintf("%s #%d", l, seen[l])
+		}
+	}
+	return labels
+}
+
+// metric busca la métrica de una fila
+func metric(name string) compareMetric {
+	for _, m := range compareMetrics {
+		if m.name == name {
+			return m
+		}
+	}
+	return compareMetric{name: name, label: name}
+}
+
+// RenderComparison escribe la comparación como tabla, una columna por
+// localización y un bloque de filas por día, con el mejor valor de cada fila
+// resaltado. Si las columnas no caben en opt.Width se reparten en varias
+// tablas; sin colores el mejor valor se marca con un asterisco.
+func RenderComparison(c Comparison, out io.Writer, opt Options) {
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+	u := opt.units()
+	if len(c.Days) == 0 {
+		_, _ = fmt.Fprintln(out, l.Sprintf("No forecast available."))
+		return
+	}
+
+	// Celdas en texto plano, para alinear antes de colorear
+	names := locationLabels(c.Locations)
+	widths := make([]int, len(c.Locations))
+	for j, name := range names {
+		widths[j] = len([]rune(name))
+	}
+	labelW := 0
+	cells := make([][][]string, len(c.Days))
+	for i, d := range c.Days {
+		cells[i] = make([][]string, len(d.Rows))
+		for r, row := range d.Rows {
+			m := metric(row.Metric)
+			labelW = max(labelW, len([]rune(l.Sprintf(m.label))))
+			cells[i][r] = make([]string, len(row.Values))
+			for j, v := range row.Values {
+				s := "–"
+				if v != nil {
+					s = m.show(u, l, *v)
+				}
+				if row.Best[j] && !opt.Color {
+					s = "*" + s
+				}
+				cells[i][r][j] = s
+				widths[j] = max(widths[j], len([]rune(s)))
+			}
+		}
+	}
+	labelW += 2
+
+	pad := func(s string, w int) string {
+		return strings.Repeat(" ", max(w-len([]rune(s)), 0)) + s
+	}
+	for b, cols := range compareBlocks(labelW, widths, opt.Width) {
+		if b > 0 {
+			_, _ = fmt.Fprintln(out)
+		}
+		line := strings.Repeat(" ", labelW)
+		for _, j := range cols {
+			line += "  " + th.bold(th.header(pad(names[j], widths[j])))
+		}
+		_, _ = fmt.Fprintln(out, line)
+
+		for i, d := range c.Days {
+			_, _ = fmt.Fprintln(out, th.bold(l.Date(d.Date)))
+			for r, row := range d.Rows {
+				label := "  " + l.Sprintf(metric(row.Metric).label)
+				line := th.label(label + strings.Repeat(" ", labelW-len([]rune(label))))
+				for _, j := range cols {
+					cell := pad(cells[i][r][j], widths[j])
+					switch {
+					case row.Values[j] == nil:
+						cell = th.dim(cell)
+					case row.Best[j]:
+						cell = th.bold(th.ok(cell))
+					default:
+						cell = th.value(cell)
+					}
+					line += "  " + cell
+				}
+				_, _ = fmt.Fprintln(out, line)
+			}
+		}
+	}
+	if !opt.Color {
+		_, _ = fmt.Fprintln(out, "\n"+l.Sprintf("* best value of the row"))
+	}
+}
+
+// compareBlocks reparte las columnas en grupos que quepan en width junto a
+// la de etiquetas; width 0 no limita. Cada grupo lleva al menos una columna.
+func compareBlocks(labelW int, widths []int, width int) [][]int {
+	var blocks [][]int
+	var cur []int
+	used := labelW
+	for j, w := range widths {
+		if width > 0 && len(cur) > 0 && used+2+w > width {
+			blocks = append(blocks, cur)
+			cur, used = nil, labelW
+		}
+		cur = append(cur, j)
+		used += 2 + w
+	}
+	return append(blocks, cur)
+}
+
+// JSONComparison es la salida de compare --output json. Los valores van en
+// las unidades de Units; Best da las posiciones en Locations de las que
+// tienen el mejor valor, porque dos localizaciones pueden llamarse igual.
+type JSONComparison struct {
+	Units     JSONUnits           `json:"units"`
+	Locations []JSONLocation      `json:"locations"`
+	Days      []JSONComparisonDay `json:"days"`
+}
+
+type JSONComparisonDay struct {
+	Date string              `json:"date"`
+	Rows []JSONComparisonRow `json:"rows"`
+}
+
+type JSONComparisonRow struct {
+	Metric string     `json:"metric"`
+	Unit   string     `json:"unit"`
+	Values []*float64 `json:"values"`
+	Best   []int      `json:"best"`
+}
+
+// NewJSONComparison convierte la comparación al formato de --output json en
+// las unidades de opt
+func NewJSONComparison(c Comparison, opt Options) JSONComparison {
+	u := opt.Units
+	doc := JSONComparison{Units: jsonUnits(u), Locations: []JSONLocation{}, Days: []JSONComparisonDay{}}
+	for _, loc := range c.Locations {
+		doc.Locations = append(doc.Locations, jsonLocation(loc))
+	}
+	for _, d := range c.Days {
+		day := JSONComparisonDay{Date: d.Date.Format(time.DateOnly)}
+		for _, row := range d.Rows {
+			m := metric(row.Metric)
+			jr := JSONComparisonRow{Metric: row.Metric, Unit: m.unit(u), Values: make([]*float64, len(row.Values)), Best: []int{}}
+			for j, v := range row.Values {
+				if v != nil {
+					x := m.convert(u, *v)
+					jr.Values[j] = &x
+				}
+				if row.Best[j] {
+					jr.Best = append(jr.Best, j)
+				}
+			}
+			day.Rows = append(day.Rows, jr)
+		}
+		doc.Days = append(doc.Days, day)
+	}
+	return doc
+}
+
+// RenderJSONComparison escribe doc indentado
+func RenderJSONComparison(doc JSONComparison, out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// NewComparisonTable devuelve la comparación para CSV y TSV: una fila por
+// día y métrica, una columna por localización (vacía si no tiene ese día) y
+// en best las mejores separadas por punto y coma. Las columnas llevan los
+// nombres de locationLabels, que no se repiten.
+func NewComparisonTable(c Comparison, u units.System) Table {
+	labels := locationLabels(c.Locations)
+	t := Table{Columns: []string{"date", "metric", "unit"}}
+	t.Columns = append(t.Columns, labels...)
+	t.Columns = append(t.Columns, "best")
+	for _, d := range c.Days {
+		for _, row := range d.Rows {
+			m := metric(row.Metric)
+			cells := []any{d.Date.Format(time.DateOnly), row.Metric, m.unit(u)}
+			var best []string
+			for j, v := range row.Values {
+				if v == nil {
+					cells = append(cells, "")
+				} else {
+					cells = append(cells, m.convert(u, *v))
+				}
+				if row.Best[j] {
+					best = append(best, labels[j])
+				}
+			}
+			t.Rows = append(t.Rows, append(cells, strings.Join(best, ";")))
+		}
+	}
+	return t
+}
@@ -0,0 +1,170 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
+	"testing"
+	"time"
+)
+
+func compareSample() []*weather.Forecast {
+	day := func(date time.Time, maxC, minC float64, rain int, wind, uv float64) weather.Day {
+		return weather.Day{Date: date, MaxTempC: maxC, MinTempC: minC, ChanceOfRain: rain, MaxWindKph: wind, UV: uv}
+	}
+	d1 := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	d2 := d1.AddDate(0, 0, 1)
+	return []*weather.Forecast{
+		{Location: weather.Location{Name: "Vigo"}, Days: []weather.Day{day(d1, 20, 14, 60, 20, 3), day(d2, 19, 13, 80, 30, 2)}},
+		{Location: weather.Location{Name: "Madrid"}, Days: []weather.Day{day(d1, 26, 9, 0, 10, 5), day(d2, 24, 10, 0, 12, 5)}},
+		{Location: weather.Location{Name: "Lisboa"}, Days: []weather.Day{day(d1, 23, 16, 10, 10, 4)}},
+	}
+}
+
+func TestNewComparison_Best(t *testing.T) {
+	c := NewComparison(compareSample(), 3)
+	if len(c.Days) != 2 {
+		t.Fatalf("got %d days, want 2", len(c.Days))
+	}
+	best := func(day, row int) []bool { return c.Days[day].Rows[row].Best }
+	// Máxima más cercana a 22 °C, mínima más alta, y el resto lo más bajo
+	// (con empates)
+	want := [][]bool{
+		{false, false, true},
+		{false, false, true},
+		{false, true, false},
+		{false, true, true},
+		{true, false, false},
+	}
+	for r, w := range want {
+		for j := range w {
+			if best(0, r)[j] != w[j] {
+				t.Errorf("day 0 %s: best = %v, want %v", c.Days[0].Rows[r].Metric, best(0, r), w)
+				break
+			}
+		}
+	}
+	// Lisboa no tiene segundo día
+	if c.Days[1].Rows[0].Values[2] != nil || c.Days[1].Rows[0].Best[2] {
+		t.Errorf("missing day: %+v", c.Days[1].Rows[0])
+	}
+}
+
+func TestRenderComparison(t *testing.T) {
+	c := NewComparison(compareSample(), 1)
+	var buf bytes.Buffer
+	RenderComparison(c, &buf, Options{Locale: i18n.New("en")})
+	want := "" +
+		"               Vigo    Madrid    Lisboa\n" +
+		"Mon 19 Oct 2026\n" +
+		"  Max temp     20°C      26°C     *23°C\n" +
+		"  Min temp     14°C       9°C     *16°C\n" +
+		"  Rain          60%       *0%       10%\n" +
+		"  Wind      20 km/h  *10 km/h  *10 km/h\n" +
+		"  UV             *3         5         4\n" +
+		"\n* best value of the row\n"
+	if buf.String() != want {
+		t.Errorf("RenderComparison =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// En 30 columnas cada localización va en su propia tabla
+	buf.Reset()
+	RenderComparison(c, &buf, Options{Locale: i18n.New("en"), Width: 30})
+	if n := bytes.Count(buf.Bytes(), []byte("Mon 19 Oct 2026")); n != 2 {
+		t.Errorf("expected 2 blocks at width 30, got %d:\n%s", n, buf.String())
+	}
+}
+
+func TestComparison_JSONAndTable(t *testing.T) {
+	c := NewComparison(compareSample(), 2)
+	imperial, _ := units.Parse("imperial")
+
+	doc := NewJSONComparison(c, Options{Units: imperial})
+	data, err := json.Marshal(doc.Days[1].Rows[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"metric":"max_temp","unit":"°F","values":[66.2,75.2,null],"best":[1]}`; string(data) != want {
+		t.Errorf("json row = %s, want %s", data, want)
+	}
+
+	tab := NewComparisonTable(c, imperial)
+	var buf bytes.Buffer
+	if err := RenderCSV(tab, &buf, true); err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(buf.Bytes(), []byte("\n"))
+	if got, want := string(lines[0]), "date,metric,unit,Vigo,Madrid,Lisboa,best"; got != want {
+		t.Errorf("header = %q, want %q", got, want)
+	}
+	if got, want := string(lines[9]), "2026-10-20,max_wind,mph,18.64,7.46,,Madrid"; got != want {
+		t.Errorf("row = %q, want %q", got, want)
+	}
+}
+
+// Una localización que empieza un día más tarde no mezcla sus fechas con las
+// de las demás
+func TestNewComparison_OffsetDays(t *testing.T) {
+	d1 := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	t1 := time.Date(2026, 10, 20, 0, 0, 0, 0, tokyo)
+	fs := []*weather.Forecast{
+		{Location: weather.Location{Name: "Vigo"}, Days: []weather.Day{{Date: d1, MaxTempC: 30}, {Date: d1.AddDate(0, 0, 1), MaxTempC: 25}}},
+		{Location: weather.Location{Name: "Tokyo"}, Days: []weather.Day{{Date: t1, MaxTempC: 22}, {Date: t1.AddDate(0, 0, 1), MaxTempC: 21}}},
+	}
+
+	c := NewComparison(fs, 3)
+	want := []string{"2026-10-19", "2026-10-20", "2026-10-21"}
+	if len(c.Days) != len(want) {
+		t.Fatalf("got %d days, want %d", len(c.Days), len(want))
+	}
+	for i, d := range c.Days {
+		if got := d.Date.Format(time.DateOnly); got != want[i] {
+			t.Errorf("day %d: date %s, want %s", i, got, want[i])
+		}
+	}
+	maxTemp := func(day int) []*float64 { return c.Days[day].Rows[0].Values }
+	if v := maxTemp(0); v[0] == nil || *v[0] != 30 || v[1] != nil || !c.Days[0].Rows[0].Best[0] {
+		t.Errorf("19th: only Vigo must have a value: %+v", c.Days[0].Rows[0])
+	}
+	if v := maxTemp(1); v[0] == nil || *v[0] != 25 || v[1] == nil || *v[1] != 22 {
+		t.Errorf("20th: values %v", v)
+	}
+	if best := c.Days[1].Rows[0].Best; best[0] || !best[1] {
+		t.Errorf("20th: best = %v, want Tokyo", best)
+	}
+	if v := maxTemp(2); v[0] != nil || v[1] == nil || *v[1] != 21 {
+		t.Errorf("21st: only Tokyo must have a value: %v", v)
+	}
+}
+
+// Dos localizaciones con el mismo nombre no comparten columna ni se
+// confunden en best
+func TestComparison_SameName(t *testing.T) {
+	d := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	fs := []*weather.Forecast{
+		{Location: weather.Location{Name: "Santiago", Region: "Galicia", Country: "Spain"}, Days: []weather.Day{{Date: d, MaxTempC: 18}}},
+		{Location: weather.Location{Name: "Santiago", Region: "Santiago Metropolitan", Country: "Chile"}, Days: []weather.Day{{Date: d, MaxTempC: 22}}},
+		{Location: weather.Location{Name: "Vigo", Region: "Galicia"}, Days: []weather.Day{{Date: d, MaxTempC: 20}}},
+		{Location: weather.Location{Name: "Vigo", Region: "Galicia"}, Days: []weather.Day{{Date: d, MaxTempC: 20}}},
+	}
+	c := NewComparison(fs, 1)
+
+	if best := NewJSONComparison(c, Options{Units: units.Metric}).Days[0].Rows[0].Best; len(best) != 1 || best[0] != 1 {
+		t.Errorf("json best = %v, want [1]", best)
+	}
+
+	var buf bytes.Buffer
+	if err := RenderCSV(NewComparisonTable(c, units.Metric), &buf, true); err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(buf.Bytes(), []byte("\n"))
+	if got, want := string(lines[0]), `date,metric,unit,"Santiago, Galicia","Santiago, Santiago Metropolitan","Vigo, Galicia #1","Vigo, Galicia #2",best`; got != want {
+		t.Errorf("header = %s, want %s", got, want)
+	}
+	if got, want := string(lines[1]), `2026-10-19,max_temp,°C,18,22,20,20,"Santiago, Santiago Metropolitan"`; got != want {
+		t.Errorf("row = %s, want %s", got, want)
+	}
+}
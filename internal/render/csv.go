@@ -0,0 +1,62 @@
+package render
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// RenderCSV escribe t como CSV (RFC 4180): los campos con comas, comillas o
+// saltos de línea van entre comillas. header=false omite la cabecera, para
+// concatenar varias tablas con las mismas columnas.
+func RenderCSV(t Table, out io.Writer, header bool) error {
+	w := csv.NewWriter(out)
+	if header {
+		if err := w.Write(t.Columns); err != nil {
+			return err
+		}
+	}
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i, v := range row {
+			record[i] = cellString(v)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// tsvEscaper escapa los caracteres que romperían una fila TSV, con la misma
+// convención que PostgreSQL o ClickHouse (\t, \n, \r y \\)
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// RenderTSV escribe t separado por tabuladores, una fila por línea
+func RenderTSV(t Table, out io.Writer, header bool) error {
+	fields := make([]string, len(t.Columns))
+	writeRow := func(cells []string) error {
+		for i, c := range cells {
+			fields[i] = tsvEscaper.Replace(c)
+		}
+		_, err := io.WriteString(out, strings.Join(fields, "\t")+"\n")
+		return err
+	}
+
+	if header {
+		if err := writeRow(t.Columns); err != nil {
+			return err
+		}
+	}
+	cells := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i, v := range row {
+			cells[i] = cellString(v)
+		}
+		if err := writeRow(cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/weather"
+)
+
+// RenderCurrent muestra un resumen compacto de las condiciones actuales
+func RenderCurrent(f *weather.Forecast, out io.Writer, opt Options) {
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+	u := opt.units()
+	c := f.Current
+
+	loc := f.Location.Name
+	if f.Location.Country != "" {
+		loc = fmt.Sprintf("%s, %s", f.Location.Name, f.Location.Country)
+	}
+	_, _ = fmt.Fprintf(out, "%s%s %s\n",
+		em(opt.decorate(), "📍"), th.bold(loc), th.dim(c.Time.Local().Format("15:04")),
+	)
+
+	// Condición y temperatura
+	_, _ = fmt.Fprintf(out, "%s%s  %s %s\n",
+		conditionIcon(opt, c.Condition), th.value(c.Condition.Text),
+		fmtTemp(th, u, c.TempC),
+		th.dim(l.Sprintf("(feels like %s)", u.Temp(c.FeelsLikeC))),
+	)
+
+	// Viento con dirección y rachas
+	wind := u.Wind(c.WindKph)
+	if c.WindDir != "" {
+		wind += " " + c.WindDir
+	}
+	_, _ = fmt.Fprintf(out, "  %s%s %s %s  %s%s %s\n",
+		em(opt.decorate(), "💨"), th.label(l.Sprintf("wind:")), th.value(wind),
+		th.dim(l.Sprintf("(gusts %s)", u.Wind(c.GustKph))),
+		em(opt.decorate(), "💧"), th.label(l.Sprintf("humidity:")), th.value(l.Sprintf("%d%%", c.Humidity)),
+	)
+
+	_, _ = fmt.Fprintf(out, "  %s%s %s  %s%s %s  %s%s %s\n",
+		em(opt.decorate(), "🧭"), th.label(l.Sprintf("pressure:")), th.value(u.Pressure(c.PressureMb)),
+		em(opt.decorate(), "🔆"), th.label("UV:"), th.value(l.Sprintf("%.1f", c.UV)),
+		em(opt.decorate(), "👁️"), th.label(l.Sprintf("visibility:")), th.value(u.Distance(c.VisKm)),
+	)
+}
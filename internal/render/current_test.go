@@ -0,0 +1,55 @@
+package render
+
+import (
+	"bytes"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderCurrent(t *testing.T) {
+	w := weather.Forecast{
+		Location: weather.Location{Name: "Vigo", Country: "Spain"},
+		Current: weather.Current{
+			Time:       time.Now(),
+			TempC:      16.1,
+			FeelsLikeC: 15.2,
+			Condition:  weather.Condition{Text: "Fog"},
+			WindKph:    7.2,
+			WindDir:    "NNW",
+			GustKph:    9.9,
+			Humidity:   100,
+			PressureMb: 1023,
+			UV:         0.1,
+			VisKm:      0.3,
+		},
+	}
+
+	var buf bytes.Buffer
+	RenderCurrent(&w, &buf, Options{})
+	out := buf.String()
+
+	if containsANSI(out) || containsAnyEmoji(out) {
+		t.Fatalf("expected plain output, got:\n%s", out)
+	}
+	if strings.Contains(out, "%!") {
+		t.Fatalf("format error found:\n%s", out)
+	}
+	for _, want := range []string{
+		"Vigo, Spain",
+		"Fog",
+		"16°C",
+		"sensación 15°C",
+		"7 km/h NNW",
+		"rachas 10 km/h",
+		"humedad: 100%",
+		"presión: 1023 hPa",
+		"UV: 0,1",
+		"visibilidad: 0,3 km",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
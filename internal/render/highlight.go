@@ -0,0 +1,38 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+)
+
+// tokenRe parte una línea en palabras y en los espacios que las separan, para
+// poder reconstruirla tal cual
+var tokenRe = regexp.MustCompile(`\S+|\s+`)
+
+// HighlightChanges marca en vídeo inverso las palabras de cur que no estaban
+// en la misma línea y posición de prev. Las secuencias de color forman parte
+// de la palabra, así que un valor que cambia de color también se marca. Se
+// usa en el modo watch para señalar lo que cambió en el último refresco.
+func HighlightChanges(prev, cur string) string {
+	if prev == "" {
+		return cur
+	}
+	prevLines := strings.Split(prev, "\n")
+	lines := strings.Split(cur, "\n")
+	for i, line := range lines {
+		var old []string
+		if i < len(prevLines) {
+			old = tokenRe.FindAllString(prevLines[i], -1)
+		}
+		tokens := tokenRe.FindAllString(line, -1)
+		for j, tok := range tokens {
+			if strings.TrimSpace(tok) == "" || (j < len(old) && old[j] == tok) {
+				continue
+			}
+			// 27 quita solo el vídeo inverso y respeta los colores de la palabra
+			tokens[j] = "\x1b[7m" + tok + "\x1b[27m"
+		}
+		lines[i] = strings.Join(tokens, "")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,22 @@
+package render
+
+import "testing"
+
+func TestHighlightChanges(t *testing.T) {
+	prev := "Vigo  16°C  wind 10 km/h\nrain 20%"
+	cases := []struct {
+		name, prev, cur, want string
+	}{
+		{"first frame", "", prev, prev},
+		{"unchanged", prev, prev, prev},
+		{"changed values", prev, "Vigo  17°C  wind 10 km/h\nrain 80%",
+			"Vigo  \x1b[7m17°C\x1b[27m  wind 10 km/h\nrain \x1b[7m80%\x1b[27m"},
+		{"new line", "a", "a\nb", "a\n\x1b[7mb\x1b[27m"},
+		{"color change", "\x1b[34m9°C\x1b[0m", "\x1b[97m11°C\x1b[0m", "\x1b[7m\x1b[97m11°C\x1b[0m\x1b[27m"},
+	}
+	for _, c := range cases {
+		if got := HighlightChanges(c.prev, c.cur); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package render
+
+import (
+	"fmt"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+)
+
+// HourlyTable devuelve la cabecera y una fila por hora del día idx de f, con
+// las columnas alineadas y los colores del tema. La condición va al final
+// porque el ancho de los iconos varía según la terminal. Las horas se
+// muestran en la zona horaria de la localización.
+func HourlyTable(f *weather.Forecast, idx int, opt Options) []string {
+	if idx < 0 || idx >= len(f.Days) {
+		return nil
+	}
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+	u := opt.units()
+	tz := locationTZ(f)
+	hours := f.Days[idx].Hours
+
+	// Celdas en texto plano: se alinean antes de colorear para que las
+	// secuencias ANSI no cuenten en el ancho
+	header := []string{l.Sprintf("Time"), l.Sprintf("Temp"), l.Sprintf("Feels"), l.Sprintf("Rain"), l.Sprintf("Precip"), l.Sprintf("Wind")}
+	cells := make([][]string, len(hours))
+	widths := make([]int, len(header))
+	for i, h := range hours {
+		cells[i] = []string{
+			h.Time.In(tz).Format("15:04"), u.Temp(h.TempC), u.Temp(h.FeelsLikeC),
+			fmt.Sprintf("%.0f%%", h.ChanceOfRain), u.Precip(h.PrecipMm), u.Wind(h.WindKph),
+		}
+	}
+	for c := range header {
+		widths[c] = len([]rune(header[c]))
+		for _, row := range cells {
+			widths[c] = max(widths[c], len([]rune(row[c])))
+		}
+	}
+	// La hora se alinea a la izquierda y los valores a la derecha
+	pad := func(s string, c int) string {
+		gap := strings.Repeat(" ", widths[c]-len([]rune(s)))
+		if c == 0 {
+			return s + gap
+		}
+		return gap + s
+	}
+
+	var head []string
+	for c, s := range header {
+		head = append(head, pad(s, c))
+	}
+	lines := []string{th.label(strings.Join(head, "  ") + "  " + l.Sprintf("Condition"))}
+	for i, h := range hours {
+		row := cells[i]
+		lines = append(lines, strings.Join([]string{
+			th.dim(pad(row[0], 0)),
+			tempColor(th, h.TempC)(pad(row[1], 1)),
+			th.value(pad(row[2], 2)),
+			percentColor(th, h.ChanceOfRain)(pad(row[3], 3)),
+			th.value(pad(row[4], 4)),
+			th.value(pad(row[5], 5)),
+			conditionIcon(opt, h.Condition) + th.value(h.Condition.Text),
+		}, "  "))
+	}
+	return lines
+}
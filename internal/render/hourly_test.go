@@ -0,0 +1,22 @@
+package render
+
+import (
+	"mruiz/cliWeather/internal/i18n"
+	"strings"
+	"testing"
+)
+
+func TestHourlyTable(t *testing.T) {
+	lines := HourlyTable(sampleForecast(), 0, Options{Locale: i18n.New("es")})
+	want := []string{
+		"Hora   Temp  Sensación  Lluvia  Precip.   Viento  Condición",
+		"00:00  14°C       13°C     20%   0,0 mm  10 km/h  Clear",
+		"12:00  20°C       20°C     87%   0,4 mm  18 km/h  Light rain",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("table =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+	if HourlyTable(sampleForecast(), 3, Options{}) != nil {
+		t.Error("out of range day must return nil")
+	}
+}
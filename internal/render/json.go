@@ -0,0 +1,334 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
+	"time"
+)
+
+// SchemaVersion es la versión del esquema de --output json
+// (schema/forecast.v1.json). Solo cambia con cambios incompatibles: añadir
+// campos opcionales no la sube, así que los consumidores deben ignorar los
+// campos que no conozcan.
+const SchemaVersion = 1
+
+// now permite fijar fetched_at en los tests
+var now = time.Now
+
+// JSONDocument es la salida estable de --output json. Los valores ya vienen
+// convertidos a las unidades indicadas en Units.
+type JSONDocument struct {
+	SchemaVersion int          `json:"schema_version"`
+	Provider      string       `json:"provider"`
+	FetchedAt     time.Time    `json:"fetched_at"`
+	Units         JSONUnits    `json:"units"`
+	Location      JSONLocation `json:"location"`
+	Current       *JSONCurrent `json:"current,omitempty"`
+	Days          []JSONDay    `json:"days"`
+	Hours         []JSONHour   `json:"hours"`
+	Alerts        []JSONAlert  `json:"alerts,omitempty"`
+	Summary       *JSONSummary `json:"summary,omitempty"`
+}
+
+type JSONUnits struct {
+	Temperature   string `json:"temperature"`
+	WindSpeed     string `json:"wind_speed"`
+	Pressure      string `json:"pressure"`
+	Precipitation string `json:"precipitation"`
+	Snow          string `json:"snow"`
+	Visibility    string `json:"visibility"`
+}
+
+type JSONLocation struct {
+	Name     string  `json:"name"`
+	Region   string  `json:"region"`
+	Country  string  `json:"country"`
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	TimeZone string  `json:"timezone"`
+}
+
+type JSONCondition struct {
+	Text  string `json:"text"`
+	Code  int    `json:"code"`
+	IsDay bool   `json:"is_day"`
+}
+
+type JSONAirQuality struct {
+	CO           float64 `json:"co"`
+	NO2          float64 `json:"no2"`
+	O3           float64 `json:"o3"`
+	SO2          float64 `json:"so2"`
+	PM25         float64 `json:"pm2_5"`
+	PM10         float64 `json:"pm10"`
+	USEPAIndex   int     `json:"us_epa_index"`
+	GBDefraIndex int     `json:"gb_defra_index"`
+}
+
+type JSONCurrent struct {
+	Time       time.Time       `json:"time"`
+	Temp       float64         `json:"temp"`
+	FeelsLike  float64         `json:"feels_like"`
+	Condition  JSONCondition   `json:"condition"`
+	WindSpeed  float64         `json:"wind_speed"`
+	WindDegree int             `json:"wind_degree"`
+	WindDir    string          `json:"wind_dir"`
+	GustSpeed  float64         `json:"gust_speed"`
+	Humidity   int             `json:"humidity"`
+	Pressure   float64         `json:"pressure"`
+	Precip     float64         `json:"precip"`
+	Cloud      int             `json:"cloud"`
+	UV         float64         `json:"uv"`
+	Visibility float64         `json:"visibility"`
+	AirQuality *JSONAirQuality `json:"air_quality,omitempty"`
+}
+
+type JSONDay struct {
+	Date         string          `json:"date"` // AAAA-MM-DD en la zona de la localización
+	MaxTemp      float64         `json:"max_temp"`
+	MinTemp      float64         `json:"min_temp"`
+	AvgTemp      float64         `json:"avg_temp"`
+	MaxWindSpeed float64         `json:"max_wind_speed"`
+	TotalPrecip  float64         `json:"total_precip"`
+	TotalSnow    float64         `json:"total_snow"`
+	AvgHumidity  int             `json:"avg_humidity"`
+	ChanceOfRain int             `json:"chance_of_rain"`
+	ChanceOfSnow int             `json:"chance_of_snow"`
+	UV           float64         `json:"uv"`
+	Condition    JSONCondition   `json:"condition"`
+	Sunrise      string          `json:"sunrise"`
+	Sunset       string          `json:"sunset"`
+	AirQuality   *JSONAirQuality `json:"air_quality,omitempty"`
+}
+
+type JSONHour struct {
+	Time         time.Time     `json:"time"`
+	Temp         float64       `json:"temp"`
+	FeelsLike    float64       `json:"feels_like"`
+	Condition    JSONCondition `json:"condition"`
+	WindSpeed    float64       `json:"wind_speed"`
+	WindDegree   int           `json:"wind_degree"`
+	WindDir      string        `json:"wind_dir"`
+	Humidity     int           `json:"humidity"`
+	Precip       float64       `json:"precip"`
+	ChanceOfRain float64       `json:"chance_of_rain"`
+	ChanceOfSnow float64       `json:"chance_of_snow"`
+}
+
+type JSONAlert struct {
+	Headline    string     `json:"headline"`
+	Event       string     `json:"event"`
+	Severity    string     `json:"severity"`
+	Urgency     string     `json:"urgency"`
+	Areas       string     `json:"areas"`
+	Effective   *time.Time `json:"effective,omitempty"`
+	Expires     *time.Time `json:"expires,omitempty"`
+	Description string     `json:"description"`
+	Instruction string     `json:"instruction"`
+}
+
+// JSONSummary es el resumen de un rango de días (solo en history)
+type JSONSummary struct {
+	Days          int     `json:"days"`
+	TotalPrecip   float64 `json:"total_precip"`
+	TotalSnow     float64 `json:"total_snow"`
+	RainyDays     int     `json:"rainy_days"`
+	WettestDate   string  `json:"wettest_date,omitempty"`
+	WettestPrecip float64 `json:"wettest_precip"`
+	MaxTemp       float64 `json:"max_temp"`
+	MaxTempDate   string  `json:"max_temp_date"`
+	MinTemp       float64 `json:"min_temp"`
+	MinTempDate   string  `json:"min_temp_date"`
+	AvgTemp       float64 `json:"avg_temp"`
+	MaxWindSpeed  float64 `json:"max_wind_speed"`
+	MaxWindDate   string  `json:"max_wind_date"`
+}
+
+// round redondea a dos decimales para no arrastrar el ruido de las conversiones
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// NewJSONDocument convierte el modelo al esquema de --output json en las
+// unidades de opt. Current solo se incluye si el proveedor lo ha rellenado.
+// Las horas van en la zona horaria de la localización, no en la del equipo.
+func NewJSONDocument(f *weather.Forecast, opt Options) JSONDocument {
+	u := opt.Units
+	tz := locationTZ(f)
+	doc := JSONDocument{
+		SchemaVersion: SchemaVersion,
+		Provider:      f.Provider,
+		FetchedAt:     now().UTC().Truncate(time.Second),
+		Units:         jsonUnits(u),
+		Location:      jsonLocation(f.Location),
+		Days:          []JSONDay{},
+		Hours:         []JSONHour{},
+	}
+
+	if !f.Current.Time.IsZero() {
+		c := f.Current
+		doc.Current = &JSONCurrent{
+			Time:       c.Time.In(tz),
+			Temp:       round(u.TempValue(c.TempC)),
+			FeelsLike:  round(u.TempValue(c.FeelsLikeC)),
+			Condition:  jsonCondition(c.Condition),
+			WindSpeed:  round(u.WindValue(c.WindKph)),
+			WindDegree: c.WindDegree,
+			WindDir:    c.WindDir,
+			GustSpeed:  round(u.WindValue(c.GustKph)),
+			Humidity:   c.Humidity,
+			Pressure:   round(u.PressureValue(c.PressureMb)),
+			Precip:     round(u.PrecipValue(c.PrecipMm)),
+			Cloud:      c.Cloud,
+			UV:         c.UV,
+			Visibility: round(u.DistanceValue(c.VisKm)),
+			AirQuality: jsonAirQuality(c.AirQuality),
+		}
+	}
+
+	for _, d := range f.Days {
+		doc.Days = append(doc.Days, JSONDay{
+			Date:         d.Date.Format(time.DateOnly),
+			MaxTemp:      round(u.TempValue(d.MaxTempC)),
+			MinTemp:      round(u.TempValue(d.MinTempC)),
+			AvgTemp:      round(u.TempValue(d.AvgTempC)),
+			MaxWindSpeed: round(u.WindValue(d.MaxWindKph)),
+			TotalPrecip:  round(u.PrecipValue(d.TotalPrecipMm)),
+			TotalSnow:    round(u.SnowValue(d.TotalSnowCm)),
+			AvgHumidity:  d.AvgHumidity,
+			ChanceOfRain: d.ChanceOfRain,
+			ChanceOfSnow: d.ChanceOfSnow,
+			UV:           d.UV,
+			Condition:    jsonCondition(d.Condition),
+			Sunrise:      d.Astro.Sunrise,
+			Sunset:       d.Astro.Sunset,
+			AirQuality:   jsonAirQuality(d.AirQuality),
+		})
+		for _, h := range d.Hours {
+			doc.Hours = append(doc.Hours, JSONHour{
+				Time:         h.Time.In(tz),
+				Temp:         round(u.TempValue(h.TempC)),
+				FeelsLike:    round(u.TempValue(h.FeelsLikeC)),
+				Condition:    jsonCondition(h.Condition),
+				WindSpeed:    round(u.WindValue(h.WindKph)),
+				WindDegree:   h.WindDegree,
+				WindDir:      h.WindDir,
+				Humidity:     h.Humidity,
+				Precip:       round(u.PrecipValue(h.PrecipMm)),
+				ChanceOfRain: h.ChanceOfRain,
+				ChanceOfSnow: h.ChanceOfSnow,
+			})
+		}
+	}
+
+	for _, a := range f.Alerts {
+		doc.Alerts = append(doc.Alerts, JSONAlert{
+			Headline:    a.Headline,
+			Event:       a.Event,
+			Severity:    a.Severity,
+			Urgency:     a.Urgency,
+			Areas:       a.Areas,
+			Effective:   optionalTime(a.Effective.In(tz)),
+			Expires:     optionalTime(a.Expires.In(tz)),
+			Description: a.Description,
+			Instruction: a.Instruction,
+		})
+	}
+	return doc
+}
+
+// NewJSONSummary convierte el resumen de un rango a las unidades de u
+func NewJSONSummary(s weather.Summary, u units.System) *JSONSummary {
+	date := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.DateOnly)
+	}
+	return &JSONSummary{
+		Days:          s.Days,
+		TotalPrecip:   round(u.PrecipValue(s.TotalPrecipMm)),
+		TotalSnow:     round(u.SnowValue(s.TotalSnowCm)),
+		RainyDays:     s.RainyDays,
+		WettestDate:   date(s.WettestDate),
+		WettestPrecip: round(u.PrecipValue(s.WettestMm)),
+		MaxTemp:       round(u.TempValue(s.MaxTempC)),
+		MaxTempDate:   date(s.MaxTempDate),
+		MinTemp:       round(u.TempValue(s.MinTempC)),
+		MinTempDate:   date(s.MinTempDate),
+		AvgTemp:       round(u.TempValue(s.AvgTempC)),
+		MaxWindSpeed:  round(u.WindValue(s.MaxWindKph)),
+		MaxWindDate:   date(s.MaxWindDate),
+	}
+}
+
+// RenderJSON escribe doc indentado
+func RenderJSON(doc JSONDocument, out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// JSONDocumentList es la salida de forecast --output json con varias
+// localizaciones: un documento por localización, dentro de un sobre con la
+// versión del esquema ($defs/document_list)
+type JSONDocumentList struct {
+	SchemaVersion int            `json:"schema_version"`
+	Forecasts     []JSONDocument `json:"forecasts"`
+}
+
+// RenderJSONList escribe docs dentro de un JSONDocumentList indentado
+func RenderJSONList(docs []JSONDocument, out io.Writer) error {
+	if docs == nil {
+		docs = []JSONDocument{}
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(JSONDocumentList{SchemaVersion: SchemaVersion, Forecasts: docs})
+}
+
+func jsonUnits(u units.System) JSONUnits {
+	return JSONUnits{
+		Temperature:   u.TempSymbol(),
+		WindSpeed:     u.WindSymbol(),
+		Pressure:      u.PressureSymbol(),
+		Precipitation: u.PrecipSymbol(),
+		Snow:          u.SnowSymbol(),
+		Visibility:    u.DistanceSymbol(),
+	}
+}
+
+func jsonLocation(loc weather.Location) JSONLocation {
+	return JSONLocation{
+		Name:     loc.Name,
+		Region:   loc.Region,
+		Country:  loc.Country,
+		Lat:      loc.Lat,
+		Lon:      loc.Lon,
+		TimeZone: loc.TimeZone,
+	}
+}
+
+func jsonCondition(c weather.Condition) JSONCondition {
+	return JSONCondition{Text: c.Text, Code: c.Code, IsDay: c.IsDay}
+}
+
+func jsonAirQuality(aq *weather.AirQuality) *JSONAirQuality {
+	if aq == nil {
+		return nil
+	}
+	return &JSONAirQuality{
+		CO: aq.CO, NO2: aq.NO2, O3: aq.O3, SO2: aq.SO2, PM25: aq.PM25, PM10: aq.PM10,
+		USEPAIndex: aq.USEPAIndex, GBDefraIndex: aq.GBDefraIndex,
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
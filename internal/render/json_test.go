@@ -0,0 +1,206 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const schemaPath = "../../schema/forecast.v1.json"
+
+// sampleForecast imita a los proveedores: el día empieza a medianoche en la
+// zona de la localización, pero las horas llegan en UTC
+func sampleForecast() *weather.Forecast {
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, madrid)
+	day := date.UTC()
+	return &weather.Forecast{
+		Provider: "weatherapi",
+		Location: weather.Location{Name: "Vigo", Region: "Galicia", Country: "Spain", Lat: 42.23, Lon: -8.72, TimeZone: "Europe/Madrid"},
+		Current: weather.Current{
+			Time: day.Add(9 * time.Hour), TempC: 16, FeelsLikeC: 15, WindKph: 14.4, WindDegree: 250, WindDir: "WSW",
+			GustKph: 20, Humidity: 82, PressureMb: 1023, PrecipMm: 0.1, Cloud: 75, UV: 0.1, VisKm: 10,
+			Condition:  weather.Condition{Text: "Light rain", Code: 1183, IsDay: true},
+			AirQuality: &weather.AirQuality{PM25: 12.3, USEPAIndex: 1, GBDefraIndex: 2},
+		},
+		Days: []weather.Day{{
+			Date: date, MaxTempC: 20, MinTempC: 14, AvgTempC: 16.5, MaxWindKph: 20.2, TotalPrecipMm: 1.2,
+			AvgHumidity: 82, ChanceOfRain: 87, UV: 5,
+			Condition: weather.Condition{Text: "Patchy rain nearby", Code: 1063, IsDay: true},
+			Astro:     weather.Astro{Sunrise: "08:41 AM", Sunset: "07:33 PM"},
+			Hours: []weather.Hour{
+				{Time: day, TempC: 14.2, FeelsLikeC: 13, WindKph: 10, WindDegree: 200, WindDir: "SSW", Humidity: 90, ChanceOfRain: 20,
+					Condition: weather.Condition{Text: "Clear", Code: 1000}},
+				{Time: day.Add(12 * time.Hour), TempC: 19.6, FeelsLikeC: 19.6, WindKph: 18, Humidity: 70, PrecipMm: 0.4, ChanceOfRain: 87,
+					Condition: weather.Condition{Text: "Light rain", Code: 1183, IsDay: true}},
+			},
+		}},
+		Alerts: []weather.Alert{{Headline: "Yellow warning", Event: "Wind", Severity: "Moderate", Effective: day.Add(6 * time.Hour)}},
+	}
+}
+
+func compileSchema(t *testing.T) *jsonschema.Schema {
+	t.Helper()
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft2020
+	c.AssertFormat = true
+	s, err := c.Compile(schemaPath)
+	if err != nil {
+		t.Fatalf("compile %s: %v", schemaPath, err)
+	}
+	return s
+}
+
+// validate comprueba que doc, una vez serializado, cumple el esquema publicado
+func validate(t *testing.T, schema *jsonschema.Schema, doc JSONDocument) map[string]any {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := RenderJSON(doc, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.Validate(v); err != nil {
+		t.Fatalf("output does not match %s: %#v\n%s", schemaPath, err, buf.String())
+	}
+	return v
+}
+
+func TestJSON_ValidatesAgainstSchema(t *testing.T) {
+	schema := compileSchema(t)
+	windy := units.Metric
+	windy.WindUnit = units.Beaufort
+
+	for name, u := range map[string]units.System{"metric": units.Metric, "imperial": units.Imperial, "beaufort": windy} {
+		t.Run(name, func(t *testing.T) {
+			validate(t, schema, NewJSONDocument(sampleForecast(), Options{Units: u}))
+		})
+	}
+
+	// Histórico: sin datos actuales y con resumen
+	f := sampleForecast()
+	f.Current = weather.Current{}
+	f.Alerts = nil
+	doc := NewJSONDocument(f, Options{})
+	doc.Summary = NewJSONSummary(weather.Summarize(f.Days), units.Metric)
+	v := validate(t, schema, doc)
+	if _, ok := v["current"]; ok {
+		t.Error("history document must not include current")
+	}
+}
+
+func TestJSON_UnitsAndValues(t *testing.T) {
+	now = func() time.Time { return time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	doc := NewJSONDocument(sampleForecast(), Options{Units: units.Imperial})
+	if doc.SchemaVersion != SchemaVersion || doc.FetchedAt.Format(time.RFC3339) != "2026-10-19T08:00:00Z" {
+		t.Fatalf("bad envelope: %+v", doc)
+	}
+	if doc.Units.Temperature != "°F" || doc.Units.WindSpeed != "mph" || doc.Units.Precipitation != "in" {
+		t.Errorf("units = %+v", doc.Units)
+	}
+	if doc.Days[0].MaxTemp != 68 || doc.Current.Pressure != 30.21 || doc.Current.WindSpeed != 8.95 {
+		t.Errorf("values not converted: max %v, pressure %v, wind %v", doc.Days[0].MaxTemp, doc.Current.Pressure, doc.Current.WindSpeed)
+	}
+	if len(doc.Hours) != 2 || doc.Days[0].Date != "2026-10-19" {
+		t.Errorf("days/hours = %+v / %d", doc.Days, len(doc.Hours))
+	}
+
+	var buf bytes.Buffer
+	if err := RenderJSON(doc, &buf); err != nil {
+		t.Fatal(err)
+	}
+	// Los proveedores dan las horas en UTC; el documento las pasa a la zona
+	// de la localización
+	for _, want := range []string{
+		`"time": "2026-10-19T09:00:00+02:00"`,
+		`"time": "2026-10-19T12:00:00+02:00"`,
+		`"effective": "2026-10-19T06:00:00+02:00"`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %s, times must use the location offset:\n%s", want, buf.String())
+		}
+	}
+}
+
+// Con varias localizaciones forecast escribe una lista que también describe
+// el esquema
+func TestJSON_ListValidatesAgainstSchema(t *testing.T) {
+	schema := compileSchema(t)
+	oslo := sampleForecast()
+	oslo.Location = weather.Location{Name: "Oslo", Country: "Norway", Lat: 59.91, Lon: 10.75, TimeZone: "Europe/Oslo"}
+	docs := []JSONDocument{NewJSONDocument(sampleForecast(), Options{}), NewJSONDocument(oslo, Options{})}
+
+	for _, docs := range [][]JSONDocument{docs, nil} {
+		var buf bytes.Buffer
+		if err := RenderJSONList(docs, &buf); err != nil {
+			t.Fatal(err)
+		}
+		var v map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := schema.Validate(v); err != nil {
+			t.Fatalf("list output does not match %s: %#v\n%s", schemaPath, err, buf.String())
+		}
+		if got := len(v["forecasts"].([]any)); got != len(docs) {
+			t.Errorf("got %d forecasts, want %d", got, len(docs))
+		}
+	}
+
+	// Un array suelto no es una salida válida
+	var bare any
+	if err := json.Unmarshal([]byte(`[]`), &bare); err != nil {
+		t.Fatal(err)
+	}
+	if schema.Validate(bare) == nil {
+		t.Error("schema accepted a bare array")
+	}
+}
+
+// El esquema debe rechazar documentos incompletos o con valores no admitidos
+func TestJSON_SchemaRejectsInvalid(t *testing.T) {
+	schema := compileSchema(t)
+	for _, bad := range []string{
+		`{"schema_version": 1}`,
+		`{"schema_version": 2, "provider": "x", "fetched_at": "2026-10-19T08:00:00Z", "units": {"temperature": "°C", "wind_speed": "km/h", "pressure": "hPa", "precipitation": "mm", "snow": "cm", "visibility": "km"}, "location": {"name": "", "region": "", "country": "", "lat": 0, "lon": 0, "timezone": ""}, "days": [], "hours": []}`,
+		`{"schema_version": 1, "provider": "x", "fetched_at": "2026-10-19T08:00:00Z", "units": {"temperature": "K", "wind_speed": "km/h", "pressure": "hPa", "precipitation": "mm", "snow": "cm", "visibility": "km"}, "location": {"name": "", "region": "", "country": "", "lat": 0, "lon": 0, "timezone": ""}, "days": [], "hours": []}`,
+	} {
+		var v any
+		if err := json.Unmarshal([]byte(bad), &v); err != nil {
+			t.Fatal(err)
+		}
+		if schema.Validate(v) == nil {
+			t.Errorf("schema accepted invalid document %s", bad)
+		}
+	}
+}
+
+// Los campos opcionales nuevos no cambian schema_version, así que quien valide
+// con el esquema publicado debe aceptar campos que aún no conoce
+func TestJSON_SchemaAllowsNewFields(t *testing.T) {
+	schema := compileSchema(t)
+	var buf bytes.Buffer
+	if err := RenderJSON(NewJSONDocument(sampleForecast(), Options{}), &buf); err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	v["new_field"] = true
+	v["location"].(map[string]any)["elevation"] = 12.0
+	v["days"].([]any)[0].(map[string]any)["pollen"] = "low"
+	if err := schema.Validate(v); err != nil {
+		t.Fatalf("schema rejected unknown fields: %#v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// RenderNDJSON escribe cada fila de t como un objeto JSON por línea, con las
+// mismas claves y en el mismo orden que las columnas de CSV/TSV
+func RenderNDJSON(t Table, out io.Writer) error {
+	var line bytes.Buffer
+	for _, row := range t.Rows {
+		line.Reset()
+		line.WriteByte('{')
+		for i, v := range row {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			key, _ := json.Marshal(t.Columns[i])
+			val, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			line.Write(key)
+			line.WriteByte(':')
+			line.Write(val)
+		}
+		line.WriteString("}\n")
+		if _, err := out.Write(line.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/config"
+	"strings"
+)
+
+// RenderPlaces lista las localizaciones guardadas en columnas, alias y
+// consulta, y marca la que es la localización por defecto (def)
+func RenderPlaces(places []config.Place, def string, out io.Writer, opt Options) {
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+	if len(places) == 0 {
+		_, _ = fmt.Fprintln(out, l.Sprintf("No saved locations. Add one with: cliweather locations add <name> <place>"))
+		return
+	}
+
+	aliasW := 0
+	for _, p := range places {
+		aliasW = max(aliasW, len([]rune(p.Alias)))
+	}
+	for _, p := range places {
+		alias := p.Alias + strings.Repeat(" ", aliasW-len([]rune(p.Alias)))
+		mark, note := "  ", ""
+		if strings.EqualFold(p.Alias, def) {
+			mark, note = "* ", "  "+th.dim(l.Sprintf("(default)"))
+		}
+		_, _ = fmt.Fprintf(out, "%s%s  %s%s\n", mark, th.label(alias), th.value(p.Query), note)
+	}
+}
@@ -0,0 +1,35 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/weather"
+)
+
+// RenderLocations lista los resultados de una búsqueda de localizaciones
+func RenderLocations(locs []weather.Location, out io.Writer, opt Options) {
+	th := makeTheme(opt.Color)
+	if len(locs) == 0 {
+		_, _ = fmt.Fprintln(out, opt.locale().Sprintf("No locations found."))
+		return
+	}
+	for _, l := range locs {
+		_, _ = fmt.Fprintf(out, "%s%s  %s  %s\n",
+			em(opt.decorate(), "📍"), th.bold(l.Name),
+			th.label(placeDetail(l)),
+			th.dim(fmt.Sprintf("%.4f,%.4f", l.Lat, l.Lon)),
+		)
+	}
+}
+
+// placeDetail devuelve "Región, País" omitiendo las partes vacías
+func placeDetail(l weather.Location) string {
+	switch {
+	case l.Region != "" && l.Country != "":
+		return l.Region + ", " + l.Country
+	case l.Region != "":
+		return l.Region
+	default:
+		return l.Country
+	}
+}
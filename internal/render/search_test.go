@@ -0,0 +1,29 @@
+package render
+
+import (
+	"bytes"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/weather"
+	"testing"
+)
+
+func TestRenderLocations(t *testing.T) {
+	locs := []weather.Location{
+		{Name: "Vigo", Region: "Galicia", Country: "Spain", Lat: 42.2328, Lon: -8.7226},
+		{Name: "Vigo", Country: "Italy", Lat: 46.4, Lon: 11.6},
+	}
+	var buf bytes.Buffer
+	RenderLocations(locs, &buf, Options{Locale: i18n.New("en")})
+	want := "" +
+		"Vigo  Galicia, Spain  42.2328,-8.7226\n" +
+		"Vigo  Italy  46.4000,11.6000\n"
+	if buf.String() != want {
+		t.Errorf("RenderLocations =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	RenderLocations(nil, &buf, Options{Locale: i18n.New("es")})
+	if want := "No se han encontrado localizaciones.\n"; buf.String() != want {
+		t.Errorf("RenderLocations(nil) = %q, want %q", buf.String(), want)
+	}
+}
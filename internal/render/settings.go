@@ -0,0 +1,51 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/config"
+	"strings"
+)
+
+// RenderSettings lista los ajustes efectivos en columnas: clave, valor y de
+// dónde sale (el --flag, la variable de entorno, el fichero o el valor por
+// defecto). Los que siguen con su valor por defecto se atenúan.
+func RenderSettings(values []config.Value, out io.Writer, opt Options) {
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+
+	keyW, valueW := len([]rune(l.Sprintf("Setting"))), len([]rune(l.Sprintf("Value")))
+	for _, v := range values {
+		keyW = max(keyW, len([]rune(v.Key)))
+		valueW = max(valueW, len([]rune(shownValue(v.Value))))
+	}
+	pad := func(s string, w int) string {
+		return s + strings.Repeat(" ", max(w-len([]rune(s)), 0))
+	}
+
+	_, _ = fmt.Fprintln(out, th.header(pad(l.Sprintf("Setting"), keyW)+"  "+pad(l.Sprintf("Value"), valueW)+"  "+l.Sprintf("Source")))
+	for _, v := range values {
+		key, value := th.label(pad(v.Key, keyW)), th.value(pad(shownValue(v.Value), valueW))
+		var source string
+		switch v.Source {
+		case config.FromFlag:
+			source = l.Sprintf("flag %s", v.Origin)
+		case config.FromEnv:
+			source = l.Sprintf("env %s", v.Origin)
+		case config.FromFile:
+			source = l.Sprintf("file")
+		default:
+			source = l.Sprintf("default")
+			value = th.dim(pad(shownValue(v.Value), valueW))
+		}
+		_, _ = fmt.Fprintf(out, "%s  %s  %s\n", key, value, source)
+	}
+}
+
+// shownValue pone comillas a los valores vacíos para que se vean
+func shownValue(s string) string {
+	if s == "" {
+		return `""`
+	}
+	return s
+}
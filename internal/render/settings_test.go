@@ -0,0 +1,46 @@
+package render
+
+import (
+	"bytes"
+	"mruiz/cliWeather/internal/config"
+	"mruiz/cliWeather/internal/i18n"
+	"testing"
+)
+
+func TestRenderSettings(t *testing.T) {
+	values := []config.Value{
+		{Key: "location", Value: "Vigo", Source: config.FromDefault},
+		{Key: "units", Value: "imperial", Source: config.FromFile, Origin: "/home/me/.config/cliweather/config.yaml"},
+		{Key: "theme.color", Value: "never", Source: config.FromFlag, Origin: "--no-color"},
+		{Key: "wind_unit", Value: "", Source: config.FromEnv, Origin: "CLIWEATHER_WIND_UNIT"},
+	}
+	var buf bytes.Buffer
+	RenderSettings(values, &buf, Options{Locale: i18n.New("es")})
+	want := "" +
+		"Ajuste       Valor     Origen\n" +
+		"location     Vigo      por defecto\n" +
+		"units        imperial  fichero\n" +
+		"theme.color  never     flag --no-color\n" +
+		"wind_unit    \"\"        entorno CLIWEATHER_WIND_UNIT\n"
+	if buf.String() != want {
+		t.Errorf("RenderSettings =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestRenderPlaces(t *testing.T) {
+	places := []config.Place{{Alias: "casa", Query: "42.23,-8.72"}, {Alias: "oficina", Query: "Santiago de Compostela"}}
+	var buf bytes.Buffer
+	RenderPlaces(places, "oficina", &buf, Options{Locale: i18n.New("es")})
+	want := "" +
+		"  casa     42.23,-8.72\n" +
+		"* oficina  Santiago de Compostela  (por defecto)\n"
+	if buf.String() != want {
+		t.Errorf("RenderPlaces =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	RenderPlaces(nil, "", &buf, Options{Locale: i18n.New("es")})
+	if want := "No hay localizaciones guardadas. Añade una con: cliweather locations add <nombre> <lugar>\n"; buf.String() != want {
+		t.Errorf("RenderPlaces(nil) = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,115 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/icons"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+	"time"
+)
+
+// tooltipHours es cuántas horas de previsión lleva el tooltip de Waybar
+const tooltipHours = 12
+
+// Colores de las barras según la temperatura, con los mismos umbrales que
+// tempColor en la salida de texto
+const (
+	barHot  = "#e06c75"
+	barCold = "#61afef"
+)
+
+// statusText es la línea compacta común a todas las barras: icono,
+// temperatura y condición
+func statusText(f *weather.Forecast, opt Options) string {
+	c := f.Current
+	return fmt.Sprintf("%s%s %s", conditionIcon(opt, c.Condition), opt.units().Temp(c.TempC), c.Condition.Text)
+}
+
+// barColor devuelve el color de la temperatura actual, o "" si es templada
+func barColor(f *weather.Forecast) string {
+	switch c := f.Current.TempC; {
+	case c >= 30:
+		return barHot
+	case c <= 10:
+		return barCold
+	default:
+		return ""
+	}
+}
+
+// RenderStatusLine escribe una sola línea sin colores ANSI, pensada para
+// tmux, prompts o cualquier barra que muestre texto plano
+func RenderStatusLine(f *weather.Forecast, out io.Writer, opt Options) {
+	_, _ = fmt.Fprintln(out, statusText(f, opt))
+}
+
+// WaybarOutput es el JSON que espera un módulo custom de Waybar con
+// "return-type": "json"
+type WaybarOutput struct {
+	Text    string `json:"text"`
+	Alt     string `json:"alt"`
+	Tooltip string `json:"tooltip"`
+	Class   string `json:"class"`
+}
+
+// pangoEscaper escapa el texto del tooltip, que Waybar interpreta como Pango
+var pangoEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// RenderWaybar escribe la salida para Waybar. class es el tipo de condición
+// (rain, snow, clear...) para que el estilo CSS pueda reaccionar a él, y el
+// tooltip lista las próximas horas.
+func RenderWaybar(f *weather.Forecast, out io.Writer, opt Options) error {
+	kind := icons.KindOf(f.Current.Condition.Code).String()
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	return enc.Encode(WaybarOutput{
+		Text:    pangoEscaper.Replace(statusText(f, opt)),
+		Alt:     kind,
+		Tooltip: pangoEscaper.Replace(hourlyTooltip(f, opt)),
+		Class:   kind,
+	})
+}
+
+// hourlyTooltip resume la localización y las próximas tooltipHours horas
+func hourlyTooltip(f *weather.Forecast, opt Options) string {
+	u := opt.units()
+	l := opt.locale()
+	tz := locationTZ(f)
+
+	lines := []string{f.Location.Name}
+	from := now().Truncate(time.Hour)
+	for _, d := range f.Days {
+		for _, h := range d.Hours {
+			if h.Time.Before(from) || len(lines) > tooltipHours {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s  %s%s  %s  %s",
+				h.Time.In(tz).Format("15:04"), conditionIcon(opt, h.Condition),
+				u.Temp(h.TempC), l.Sprintf("%.0f%%", h.ChanceOfRain), u.Wind(h.WindKph),
+			))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RenderI3Blocks escribe las tres líneas del protocolo de i3blocks: texto
+// completo, texto corto y color
+func RenderI3Blocks(f *weather.Forecast, out io.Writer, opt Options) {
+	short := conditionIcon(opt, f.Current.Condition) + opt.units().Temp(f.Current.TempC)
+	_, _ = fmt.Fprintf(out, "%s\n%s\n%s\n", statusText(f, opt), short, barColor(f))
+}
+
+// RenderPolybar escribe una línea con las etiquetas de formato de Polybar
+// para colorear la temperatura
+func RenderPolybar(f *weather.Forecast, out io.Writer, opt Options) {
+	c := f.Current
+	temp := opt.units().Temp(c.TempC)
+	if color := barColor(f); color != "" {
+		temp = "%{F" + color + "}" + temp + "%{F-}"
+	}
+	// Polybar interpreta "%{"; un "%" suelto en el texto se duplica
+	text := strings.ReplaceAll(c.Condition.Text, "%", "%%")
+	_, _ = fmt.Fprintf(out, "%s%s %s\n", conditionIcon(opt, c.Condition), temp, text)
+}
@@ -0,0 +1,76 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"mruiz/cliWeather/internal/units"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderStatusLine(t *testing.T) {
+	var buf bytes.Buffer
+	RenderStatusLine(sampleForecast(), &buf, Options{Emoji: true, Color: true})
+	if got := buf.String(); got != "🌧️ 16°C Light rain\n" {
+		t.Errorf("statusline = %q", got)
+	}
+
+	buf.Reset()
+	RenderStatusLine(sampleForecast(), &buf, Options{Units: units.Imperial})
+	if got := buf.String(); got != "61°F Light rain\n" {
+		t.Errorf("statusline without emoji = %q", got)
+	}
+}
+
+func TestRenderWaybar(t *testing.T) {
+	f := sampleForecast()
+	now = func() time.Time { return f.Days[0].Hours[1].Time.Add(-30 * time.Minute) }
+	defer func() { now = time.Now }()
+	f.Location.Name = "A & <B>"
+
+	var buf bytes.Buffer
+	if err := RenderWaybar(f, &buf, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("waybar output must be a single line: %q", buf.String())
+	}
+	var got WaybarOutput
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Text != "16°C Light rain" || got.Class != "rain" || got.Alt != "rain" {
+		t.Errorf("waybar = %+v", got)
+	}
+	// Solo las horas desde la actual, en la zona de la localización y con el
+	// nombre escapado para Pango
+	if want := "A &amp; &lt;B&gt;\n12:00  20°C  87%  18 km/h"; got.Tooltip != want {
+		t.Errorf("tooltip = %q, want %q", got.Tooltip, want)
+	}
+}
+
+func TestRenderI3BlocksAndPolybar(t *testing.T) {
+	f := sampleForecast()
+	f.Current.TempC = 32
+	f.Current.Condition.Text = "100% sun"
+
+	var buf bytes.Buffer
+	RenderI3Blocks(f, &buf, Options{})
+	if got := buf.String(); got != "32°C 100% sun\n32°C\n"+barHot+"\n" {
+		t.Errorf("i3blocks = %q", got)
+	}
+
+	buf.Reset()
+	RenderPolybar(f, &buf, Options{})
+	if got := buf.String(); got != "%{F"+barHot+"}32°C%{F-} 100%% sun\n" {
+		t.Errorf("polybar = %q", got)
+	}
+
+	buf.Reset()
+	f.Current.TempC = 20
+	RenderI3Blocks(f, &buf, Options{})
+	if !strings.HasSuffix(buf.String(), "°C\n\n") {
+		t.Errorf("temperate i3blocks must leave the color empty: %q", buf.String())
+	}
+}
@@ -0,0 +1,43 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/weather"
+	"time"
+)
+
+// RenderSummary muestra los totales y extremos de un rango de días
+func RenderSummary(s weather.Summary, out io.Writer, opt Options) {
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+	u := opt.units()
+	if s.Days == 0 {
+		return
+	}
+	day := func(d time.Time) string { return th.dim("(" + l.ShortDate(d) + ")") }
+
+	_, _ = fmt.Fprintf(out, "\n%s %s\n", th.bold("==="), th.bold(th.header(l.Sprintf("Summary (%d days)", s.Days))))
+	_, _ = fmt.Fprintf(out, "  %s%s %s  %s%s %s\n",
+		em(opt.decorate(), "🌧️"), th.label(l.Sprintf("total rain:")), th.value(u.Precip(s.TotalPrecipMm)),
+		em(opt.decorate(), "📆"), th.label(l.Sprintf("rainy days:")), th.value(l.Sprintf("%d/%d", s.RainyDays, s.Days)),
+	)
+	if s.TotalSnowCm > 0 {
+		_, _ = fmt.Fprintf(out, "  %s%s %s\n",
+			em(opt.decorate(), "❄️"), th.label(l.Sprintf("total snow:")), th.value(u.Snow(s.TotalSnowCm)),
+		)
+	}
+	if s.WettestMm > 0 {
+		_, _ = fmt.Fprintf(out, "  %s%s %s %s\n",
+			em(opt.decorate(), "☔️"), th.label(l.Sprintf("wettest day:")), th.value(u.Precip(s.WettestMm)), day(s.WettestDate),
+		)
+	}
+	_, _ = fmt.Fprintf(out, "  %s%s %s %s  %s%s %s %s\n",
+		em(opt.decorate(), "🔺"), th.label(l.Sprintf("max:")), fmtTemp(th, u, s.MaxTempC), day(s.MaxTempDate),
+		em(opt.decorate(), "🔻"), th.label(l.Sprintf("min:")), fmtTemp(th, u, s.MinTempC), day(s.MinTempDate),
+	)
+	_, _ = fmt.Fprintf(out, "  %s%s %s  %s%s %s %s\n",
+		em(opt.decorate(), "📊"), th.label(l.Sprintf("avg:")), fmtTemp(th, u, s.AvgTempC),
+		em(opt.decorate(), "💨"), th.label(l.Sprintf("max wind:")), th.value(u.Wind(s.MaxWindKph)), day(s.MaxWindDate),
+	)
+}
@@ -0,0 +1,117 @@
+package render
+
+import (
+	"fmt"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Granularity elige si las exportaciones tabulares van por horas o por días
+type Granularity string
+
+const (
+	Hourly Granularity = "hourly"
+	Daily  Granularity = "daily"
+)
+
+// ParseGranularity valida el valor de --granularity
+func ParseGranularity(s string) (Granularity, error) {
+	switch g := Granularity(strings.ToLower(strings.TrimSpace(s))); g {
+	case Hourly, Daily:
+		return g, nil
+	default:
+		return "", fmt.Errorf("unknown granularity %q (expected hourly or daily)", s)
+	}
+}
+
+// Table es la vista tabular del modelo que comparten CSV, TSV y NDJSON. Las
+// columnas son estables y su nombre lleva la unidad (temp_c, wind_mph...);
+// las celdas guardan el valor con su tipo (float64, int, bool, string).
+type Table struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// unitSuffixes traduce el símbolo de cada unidad al sufijo de columna
+var unitSuffixes = map[string]string{
+	"°C": "c", "°F": "f",
+	"km/h": "kph", "mph": "mph", "m/s": "ms", "kn": "kn", "Bft": "bft",
+	"hPa": "hpa", "inHg": "inhg",
+	"mm": "mm", "in": "in", "cm": "cm",
+}
+
+func col(name, symbol string) string {
+	return name + "_" + unitSuffixes[symbol]
+}
+
+// NewTable construye la tabla horaria o diaria de f en las unidades de u
+func NewTable(f *weather.Forecast, u units.System, g Granularity) Table {
+	if g == Daily {
+		return dailyTable(f, u)
+	}
+	return hourlyTable(f, u)
+}
+
+// hourlyTable da las horas en la zona horaria de la localización, como JSON
+func hourlyTable(f *weather.Forecast, u units.System) Table {
+	tz := locationTZ(f)
+	t := Table{Columns: []string{
+		"location", "time",
+		col("temp", u.TempSymbol()), col("feels_like", u.TempSymbol()),
+		"condition", "condition_code", "is_day",
+		col("wind", u.WindSymbol()), "wind_degree", "wind_dir",
+		"humidity_pct", col("precip", u.PrecipSymbol()),
+		"chance_of_rain_pct", "chance_of_snow_pct",
+	}}
+	for _, d := range f.Days {
+		for _, h := range d.Hours {
+			t.Rows = append(t.Rows, []any{
+				f.Location.Name, h.Time.In(tz),
+				round(u.TempValue(h.TempC)), round(u.TempValue(h.FeelsLikeC)),
+				h.Condition.Text, h.Condition.Code, h.Condition.IsDay,
+				round(u.WindValue(h.WindKph)), h.WindDegree, h.WindDir,
+				h.Humidity, round(u.PrecipValue(h.PrecipMm)),
+				h.ChanceOfRain, h.ChanceOfSnow,
+			})
+		}
+	}
+	return t
+}
+
+func dailyTable(f *weather.Forecast, u units.System) Table {
+	t := Table{Columns: []string{
+		"location", "date",
+		col("max_temp", u.TempSymbol()), col("min_temp", u.TempSymbol()), col("avg_temp", u.TempSymbol()),
+		col("max_wind", u.WindSymbol()),
+		col("total_precip", u.PrecipSymbol()), col("total_snow", u.SnowSymbol()),
+		"avg_humidity_pct", "chance_of_rain_pct", "chance_of_snow_pct", "uv",
+		"condition", "condition_code", "sunrise", "sunset",
+	}}
+	for _, d := range f.Days {
+		t.Rows = append(t.Rows, []any{
+			f.Location.Name, d.Date.Format(time.DateOnly),
+			round(u.TempValue(d.MaxTempC)), round(u.TempValue(d.MinTempC)), round(u.TempValue(d.AvgTempC)),
+			round(u.WindValue(d.MaxWindKph)),
+			round(u.PrecipValue(d.TotalPrecipMm)), round(u.SnowValue(d.TotalSnowCm)),
+			d.AvgHumidity, d.ChanceOfRain, d.ChanceOfSnow, d.UV,
+			d.Condition.Text, d.Condition.Code, d.Astro.Sunrise, d.Astro.Sunset,
+		})
+	}
+	return t
+}
+
+// cellString formatea una celda para CSV/TSV: punto decimal sin ceros
+// sobrantes y horas en RFC 3339, sea cual sea el idioma
+func cellString(v any) string {
+	switch x := v.(type) {
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case time.Time:
+		return x.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(x)
+	}
+}
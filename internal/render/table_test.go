@@ -0,0 +1,110 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"mruiz/cliWeather/internal/units"
+	"strings"
+	"testing"
+)
+
+func TestTable_UnitAwareColumns(t *testing.T) {
+	f := sampleForecast()
+
+	hourly := NewTable(f, units.Metric, Hourly)
+	if got := strings.Join(hourly.Columns, ","); got != "location,time,temp_c,feels_like_c,condition,condition_code,is_day,wind_kph,wind_degree,wind_dir,humidity_pct,precip_mm,chance_of_rain_pct,chance_of_snow_pct" {
+		t.Errorf("hourly columns = %s", got)
+	}
+	if len(hourly.Rows) != 2 {
+		t.Fatalf("hourly rows = %d", len(hourly.Rows))
+	}
+
+	daily := NewTable(f, units.Imperial, Daily)
+	if got := strings.Join(daily.Columns, ","); got != "location,date,max_temp_f,min_temp_f,avg_temp_f,max_wind_mph,total_precip_in,total_snow_in,avg_humidity_pct,chance_of_rain_pct,chance_of_snow_pct,uv,condition,condition_code,sunrise,sunset" {
+		t.Errorf("daily columns = %s", got)
+	}
+	if len(daily.Rows) != 1 || daily.Rows[0][2] != 68.0 {
+		t.Errorf("daily rows = %v", daily.Rows)
+	}
+	for _, row := range append(hourly.Rows, daily.Rows...) {
+		if len(row) != len(hourly.Columns) && len(row) != len(daily.Columns) {
+			t.Errorf("row length %d does not match columns", len(row))
+		}
+	}
+}
+
+func TestRenderCSV_Escaping(t *testing.T) {
+	f := sampleForecast()
+	f.Location.Name = `Vigo, "Galicia"`
+	var buf bytes.Buffer
+	if err := RenderCSV(NewTable(f, units.Metric, Daily), &buf, true); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got:\n%s", buf.String())
+	}
+	if want := `"Vigo, ""Galicia""",2026-10-19,20,14,16.5,20.2,1.2,0,82,87,0,5,Patchy rain nearby,1063,08:41 AM,07:33 PM`; lines[1] != want {
+		t.Errorf("row =\n%s\nwant\n%s", lines[1], want)
+	}
+
+	buf.Reset()
+	if err := RenderCSV(NewTable(f, units.Metric, Daily), &buf, false); err != nil {
+		t.Fatal(err)
+	}
+	if strings.HasPrefix(buf.String(), "location,") {
+		t.Error("header=false must omit the header")
+	}
+}
+
+func TestRenderTSV_Escaping(t *testing.T) {
+	f := sampleForecast()
+	f.Days[0].Hours[0].Condition.Text = "Clear\tand\ncold \\o/"
+	var buf bytes.Buffer
+	if err := RenderTSV(NewTable(f, units.Metric, Hourly), &buf, true); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	fields := strings.Split(lines[1], "\t")
+	if len(fields) != 14 {
+		t.Fatalf("expected 14 fields, got %d: %q", len(fields), lines[1])
+	}
+	if fields[1] != "2026-10-19T00:00:00+02:00" || fields[4] != `Clear\tand\ncold \\o/` || fields[6] != "false" {
+		t.Errorf("fields = %q", fields)
+	}
+}
+
+func TestRenderNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderNDJSON(NewTable(sampleForecast(), units.Imperial, Hourly), &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[0], `{"location":"Vigo","time":"2026-10-19T00:00:00+02:00","temp_f":57.56,`) {
+		t.Errorf("keys must follow column order: %s", lines[0])
+	}
+	var row map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &row); err != nil {
+		t.Fatal(err)
+	}
+	if row["condition_code"] != 1183.0 || row["is_day"] != true || row["wind_mph"] != 11.18 {
+		t.Errorf("row = %v", row)
+	}
+}
+
+func TestParseGranularity(t *testing.T) {
+	for in, want := range map[string]Granularity{"hourly": Hourly, "Daily": Daily} {
+		if got, err := ParseGranularity(in); err != nil || got != want {
+			t.Errorf("ParseGranularity(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseGranularity("weekly"); err == nil {
+		t.Error("expected error for weekly")
+	}
+}
@@ -0,0 +1,112 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
+	"sort"
+	"text/template"
+	"time"
+)
+
+// Templates son las plantillas con nombre que acepta --template. Se ejecutan
+// igual que las del usuario: el punto es el *weather.Forecast.
+var Templates = map[string]string{
+	// Una línea con la condición actual, p. ej. para un prompt o un panel
+	"oneline": `{{emoji .Current.Condition}}{{formatTemp .Current.TempC}} {{.Current.Condition.Text}} · {{.Location.Name}}
+`,
+	// Condiciones actuales, una magnitud por línea
+	"current": `{{.Location.Name}}{{with .Location.Country}}, {{.}}{{end}} {{date "15:04" .Current.Time}}
+{{emoji .Current.Condition}}{{.Current.Condition.Text}} {{color "bold" (formatTemp .Current.TempC)}} ({{formatTemp .Current.FeelsLikeC}})
+{{formatWind .Current.WindKph}}{{with .Current.WindDir}} {{.}}{{end}} · {{.Current.Humidity}}% · {{formatPressure .Current.PressureMb}}
+`,
+	// Un día por línea: mínima/máxima, probabilidad de lluvia y precipitación
+	"days": `{{range .Days}}{{shortDate .Date}}  {{emoji .Condition}}{{formatTemp .MinTempC}} / {{formatTemp .MaxTempC}}  {{.ChanceOfRain}}% {{formatPrecip .TotalPrecipMm}}
+{{end}}`,
+	// Una hora por línea, agrupadas por día
+	"hours": `{{range .Days}}{{color "header" (shortDate .Date)}}
+{{range .Hours}}  {{date "15:04" .Time}} {{emoji .Condition}}{{formatTemp .TempC}} {{printf "%.0f" .ChanceOfRain}}% {{formatWind .WindKph}}
+{{end}}{{end}}`,
+}
+
+// TemplateNames devuelve los nombres de las plantillas incluidas, ordenados
+func TemplateNames() []string {
+	names := make([]string, 0, len(Templates))
+	for name := range Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseTemplate compila text con las funciones auxiliares. Se puede llamar
+// antes de consultar al proveedor para informar pronto de los errores.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs(nil, Options{})).Parse(text)
+}
+
+// RenderTemplate ejecuta t sobre f. Las funciones auxiliares usan las
+// unidades, el idioma, los colores y los iconos de opt, y las fechas se
+// muestran en la zona horaria de la localización:
+//
+//	formatTemp, formatWind, formatPressure, formatPrecip  valor con su unidad
+//	units                  el units.System ({{units.TempSymbol}})
+//	emoji .Condition       icono de la condición seguido de espacio, o ""
+//	color "hot" "texto"    bold, dim, header, label, value, hot, cold, ok, warn
+//	date "15:04" .Time     layout de Go en la hora local de la localización
+//	weekday, shortDate, longDate  fechas traducidas
+func RenderTemplate(t *template.Template, f *weather.Forecast, out io.Writer, opt Options) error {
+	t, err := t.Clone()
+	if err != nil {
+		return err
+	}
+	return t.Funcs(templateFuncs(f, opt)).Execute(out, f)
+}
+
+func templateFuncs(f *weather.Forecast, opt Options) template.FuncMap {
+	th := makeTheme(opt.Color)
+	l := opt.locale()
+	u := opt.units()
+
+	loc := time.Local
+	if f != nil {
+		loc = locationTZ(f)
+	}
+	colors := map[string]func(string) string{
+		"bold": th.bold, "dim": th.dim,
+		"header": th.header, "label": th.label, "value": th.value,
+		"hot": th.hot, "cold": th.cold, "ok": th.ok, "warn": th.warn,
+	}
+
+	return template.FuncMap{
+		"formatTemp":     u.Temp,
+		"formatWind":     u.Wind,
+		"formatPressure": u.Pressure,
+		"formatPrecip":   u.Precip,
+		"units":          func() units.System { return u },
+		"emoji":          func(c weather.Condition) string { return conditionIcon(opt, c) },
+		"color": func(name, s string) (string, error) {
+			apply, ok := colors[name]
+			if !ok {
+				return "", fmt.Errorf("unknown color %q", name)
+			}
+			return apply(s), nil
+		},
+		"date":      func(layout string, t time.Time) string { return t.In(loc).Format(layout) },
+		"weekday":   func(t time.Time) string { return l.Weekday(t.In(loc)) },
+		"shortDate": func(t time.Time) string { return l.ShortDate(t.In(loc)) },
+		"longDate":  func(t time.Time) string { return l.Date(t.In(loc)) },
+	}
+}
+
+// locationTZ devuelve la zona horaria de la localización de f, o la local si
+// el proveedor no la indica
+func locationTZ(f *weather.Forecast) *time.Location {
+	if f.Location.TimeZone != "" {
+		if tz, err := time.LoadLocation(f.Location.TimeZone); err == nil {
+			return tz
+		}
+	}
+	return time.Local
+}
@@ -0,0 +1,96 @@
+package render
+
+import (
+	"bytes"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/units"
+	"strings"
+	"testing"
+	"time"
+)
+
+func renderTemplate(t *testing.T, text string, opt Options) (string, error) {
+	t.Helper()
+	tmpl, err := ParseTemplate("test", text)
+	if err != nil {
+		t.Fatalf("parse %q: %v", text, err)
+	}
+	var buf bytes.Buffer
+	err = RenderTemplate(tmpl, sampleForecast(), &buf, opt)
+	return buf.String(), err
+}
+
+func TestTemplate_Builtins(t *testing.T) {
+	for _, name := range TemplateNames() {
+		out, err := renderTemplate(t, Templates[name], Options{Emoji: true})
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !strings.HasSuffix(out, "\n") || strings.Contains(out, "<no value>") {
+			t.Errorf("%s rendered %q", name, out)
+		}
+	}
+
+	out, _ := renderTemplate(t, Templates["oneline"], Options{Emoji: true})
+	if out != "🌧️ 16°C Light rain · Vigo\n" {
+		t.Errorf("oneline = %q", out)
+	}
+}
+
+func TestTemplate_Helpers(t *testing.T) {
+	cases := []struct {
+		text string
+		opt  Options
+		want string
+	}{
+		{`{{formatTemp .Current.TempC}} {{formatWind .Current.WindKph}}`, Options{Units: units.Imperial}, "61°F 9 mph"},
+		{`{{units.TempSymbol}}/{{(units).PrecipSymbol}}`, Options{Units: units.Imperial}, "°F/in"},
+		{`{{emoji .Current.Condition}}{{.Current.Condition.Text}}`, Options{}, "Light rain"},
+		{`{{color "hot" "x"}}`, Options{Color: true}, "\x1b[31mx\x1b[0m"},
+		{`{{color "hot" "x"}}`, Options{}, "x"},
+		{`{{weekday .Current.Time}} {{shortDate .Current.Time}}`, Options{Locale: i18n.New("en")}, "Monday Mon 19 Oct"},
+		{`{{range .Days}}{{longDate .Date}}{{end}}`, Options{}, "lun 19 oct 2026"},
+	}
+	for _, c := range cases {
+		got, err := renderTemplate(t, c.text, c.opt)
+		if err != nil || got != c.want {
+			t.Errorf("%s = %q, %v; want %q", c.text, got, err, c.want)
+		}
+	}
+
+	if _, err := renderTemplate(t, `{{color "pink" "x"}}`, Options{}); err == nil {
+		t.Error("expected error for unknown color")
+	}
+	if _, err := ParseTemplate("bad", `{{nope .Current}}`); err == nil {
+		t.Error("expected parse error for unknown function")
+	}
+}
+
+func TestTemplate_DatesInLocationTimezone(t *testing.T) {
+	tmpl, err := ParseTemplate("tz", `{{date "15:04 MST" .Current.Time}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := sampleForecast()
+	f.Current.Time = f.Current.Time.UTC()
+	f.Location.TimeZone = "America/New_York"
+
+	var buf bytes.Buffer
+	if err := RenderTemplate(tmpl, f, &buf, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	want := f.Current.Time.In(mustLoad(t, "America/New_York")).Format("15:04 MST")
+	if buf.String() != want || want != "03:00 EDT" {
+		t.Errorf("date = %q, want %q", buf.String(), want)
+	}
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	return loc
+}
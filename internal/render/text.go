@@ -3,15 +3,40 @@ package render
 import (
 	"fmt"
 	"io"
-	"mruiz/cliWeather/internal/api/weatherapi"
-	"strings"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/icons"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
 	"time"
 )
 
 // Options controla cómo se muestra el texto
 type Options struct {
-	Color bool
-	Emoji bool
+	Color  bool
+	Emoji  bool
+	Units  units.System // el valor cero es el sistema métrico
+	Locale *i18n.Locale // idioma de etiquetas, fechas y números; nil es español
+	Icons  *icons.Set   // iconos de condición; nil es icons.Emoji
+	Chart  ChartKind    // con gráfica, RenderDay omite la lista de horas
+	Width  int          // ancho de la terminal para las gráficas; 0 es 80
+}
+
+var defaultLocale = i18n.Default()
+
+// locale devuelve el idioma de la salida
+func (o Options) locale() *i18n.Locale {
+	if o.Locale == nil {
+		return defaultLocale
+	}
+	return o.Locale
+}
+
+// units devuelve el sistema de unidades formateando números según el idioma
+func (o Options) units() units.System {
+	if o.Units.Printer != nil {
+		return o.Units
+	}
+	return o.Units.WithPrinter(o.locale())
 }
 
 // ======= Tema de colores ANSI =======
@@ -68,36 +93,30 @@ func em(enabled bool, s string) string {
 	return s + " "
 }
 
-func pickConditionEmoji(enabled bool, text string) string {
-	if !enabled {
-		return ""
-	}
-	lt := strings.ToLower(text)
-	switch {
-	case strings.Contains(lt, "soleado"), strings.Contains(lt, "despejado"), strings.Contains(lt, "sunny"), strings.Contains(lt, "clear"):
-		return "☀️"
-	case strings.Contains(lt, "parcial"), strings.Contains(lt, "partly"), strings.Contains(lt, "intervals"):
-		return "⛅️"
-	case strings.Contains(lt, "nublado"), strings.Contains(lt, "cloud"):
-		return "☁️"
-	case strings.Contains(lt, "lluvia"), strings.Contains(lt, "rain"), strings.Contains(lt, "chubasc"):
-		return "🌧️"
-	case strings.Contains(lt, "tormenta"), strings.Contains(lt, "thunder"):
-		return "⛈️"
-	case strings.Contains(lt, "nieve"), strings.Contains(lt, "snow"):
-		return "❄️"
-	case strings.Contains(lt, "niebla"), strings.Contains(lt, "fog"), strings.Contains(lt, "mist"):
-		return "🌫️"
-	case strings.Contains(lt, "viento"), strings.Contains(lt, "wind"):
-		return "💨"
-	default:
-		return "🌤️"
+// iconSet devuelve el juego de iconos elegido
+func (o Options) iconSet() *icons.Set {
+	if o.Icons == nil {
+		return icons.Emoji
 	}
+	return o.Icons
+}
+
+// decorate indica si se muestran los emojis decorativos de las etiquetas
+func (o Options) decorate() bool {
+	return o.Emoji && o.iconSet().Decorations
+}
+
+// conditionIcon devuelve el icono de la condición según su código y si es de
+// día, ya con el espacio separador, o "" si los iconos están desactivados
+func conditionIcon(opt Options, c weather.Condition) string {
+	return em(opt.Emoji, opt.iconSet().Condition(c.Code, c.IsDay))
 }
 
 // ======= Helpers de formateo =======
 
-// tempColor elige el color según temperatura (Celsius)
+// tempColor elige el color según temperatura. Los umbrales se aplican sobre
+// el valor en Celsius del modelo, antes de convertir, así que son los mismos
+// sea cual sea la unidad mostrada.
 func tempColor(th theme, c float64) func(string) string {
 	switch {
 	case c >= 30:
@@ -109,128 +128,158 @@ func tempColor(th theme, c float64) func(string) string {
 	}
 }
 
-func fmtTemp(th theme, c float64) string {
+func fmtTemp(th theme, u units.System, c float64) string {
 	color := tempColor(th, c)
-	return color(fmt.Sprintf("%.0f°C", c))
+	return color(u.Temp(c))
 }
 
-// Si ChanceOfRain es int en tus tipos, cambia la firma a (th theme, p int) string
-func fmtPercent(th theme, p float64) string {
-	var apply func(string) string
+// percentColor elige el color de una probabilidad de precipitación
+func percentColor(th theme, p float64) func(string) string {
 	switch {
 	case p >= 60:
-		apply = th.warn
+		return th.warn
 	case p >= 20:
-		apply = th.value
+		return th.value
 	default:
-		apply = th.dim
+		return th.dim
 	}
-	return apply(fmt.Sprintf("%.0f%%", p))
+}
+
+// fmtPercent escribe un porcentaje con el color que le da percentColor
+func fmtPercent(th theme, p float64) string {
+	return percentColor(th, p)(fmt.Sprintf("%.0f%%", p))
 }
 
 // ======= API =======
 
-func RenderHeader(w *weatherapi.Weather, out io.Writer, opt Options) {
+func RenderHeader(f *weather.Forecast, out io.Writer, opt Options) {
 	th := makeTheme(opt.Color)
-	loc := fmt.Sprintf("%s, %s", w.Location.Name, w.Location.Country)
-	t := time.Unix(int64(w.Current.LastUpdatedEpoch), 0).Local().Format("Mon 02 Jan 2006 15:04:05 MST")
+	l := opt.locale()
+	loc := fmt.Sprintf("%s, %s", f.Location.Name, f.Location.Country)
+	t := l.DateTime(f.Current.Time.Local())
 
 	_, _ = fmt.Fprintf(out, "%s%s %s\n",
-		em(opt.Emoji, "📍")+th.header("¡Buen día! "),
+		em(opt.decorate(), "📍")+th.header(l.Sprintf("Good day!")+" "),
 		th.bold(loc),
 		"",
 	)
-	_, _ = fmt.Fprintf(out, "%s%s %s\n",
-		em(opt.Emoji, "📅"), th.label("Fecha:"), th.value(t),
-	)
+	// Los datos históricos no traen condiciones actuales ni fecha de actualización
+	if !f.Current.Time.IsZero() {
+		_, _ = fmt.Fprintf(out, "%s%s %s\n",
+			em(opt.decorate(), "📅"), th.label(l.Sprintf("Date:")), th.value(t),
+		)
+	}
+	if f.Provider != "" {
+		_, _ = fmt.Fprintf(out, "%s%s %s\n",
+			em(opt.decorate(), "🛰️"), th.label(l.Sprintf("Source:")), th.dim(f.Provider),
+		)
+	}
 }
 
-func RenderAll(w *weatherapi.Weather, out io.Writer, opt Options) error {
-	total := len(w.Forecast.Forecastday)
+func RenderAll(f *weather.Forecast, out io.Writer, opt Options) error {
+	total := len(f.Days)
 	if total == 0 {
-		_, _ = fmt.Fprintln(out, "No Forecast available.")
+		_, _ = fmt.Fprintln(out, opt.locale().Sprintf("No forecast available."))
 		return nil
 	}
-	for i := range w.Forecast.Forecastday {
-		if err := RenderDay(w, i, total, out, opt); err != nil {
+	for i := range f.Days {
+		if err := RenderDay(f, i, total, out, opt); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func RenderDay(w *weatherapi.Weather, idx, total int, out io.Writer, opt Options) error {
+func RenderDay(f *weather.Forecast, idx, total int, out io.Writer, opt Options) error {
 	th := makeTheme(opt.Color)
+	l := opt.locale()
+	u := opt.units()
 
-	if idx < 0 || idx >= len(w.Forecast.Forecastday) {
-		_, _ = fmt.Fprintf(out, "Invalid day index %d (available: 0..%d)\n", idx, len(w.Forecast.Forecastday)-1)
+	if idx < 0 || idx >= len(f.Days) {
+		_, _ = fmt.Fprintln(out, l.Sprintf("Invalid day index %d (available: 0..%d)", idx, len(f.Days)-1))
 		return nil
 	}
-	fd := w.Forecast.Forecastday[idx]
+	fd := f.Days[idx]
 
-	// Fecha del bloque
-	headerTime := time.Now().Local()
-	if len(fd.Hour) > 0 {
-		headerTime = time.Unix(int64(fd.Hour[0].TimeEpoch), 0).Local()
+	// Fecha del bloque: Date ya es la medianoche de la localización y se
+	// muestra tal cual; pasarla a la zona del equipo cambiaría de día al oeste
+	tz := locationTZ(f)
+	headerTime := fd.Date
+	if headerTime.IsZero() && len(fd.Hours) > 0 {
+		headerTime = fd.Hours[0].Time.In(tz)
+	}
+	if headerTime.IsZero() {
+		headerTime = time.Now().Local()
 	}
-	dayTitle := fmt.Sprintf("%s (día %d/%d)", headerTime.Format("Mon 02 Jan 2006"), idx+1, total)
+	dayTitle := l.Sprintf("%s (day %d/%d)", l.Date(headerTime), idx+1, total)
 	_, _ = fmt.Fprintf(out, "\n%s %s\n", th.bold("==="), th.bold(th.header(dayTitle)))
 
-	// Condición representativa
-	conditionText := w.Current.Condition.Text
-	if len(fd.Hour) > 0 {
-		mid := len(fd.Hour) / 2
-		conditionText = fd.Hour[mid].Condition.Text
+	// Condición representativa, siempre con el icono diurno
+	cond := fd.Condition
+	if len(fd.Hours) > 0 {
+		cond = fd.Hours[len(fd.Hours)/2].Condition
 	}
+	cond.IsDay = true
 
 	// Media del día por horas
-	avg := w.Current.TempC
-	if len(fd.Hour) > 0 {
+	avg := fd.AvgTempC
+	if len(fd.Hours) > 0 {
 		var sum float64
-		for _, h := range fd.Hour {
+		for _, h := range fd.Hours {
 			sum += h.TempC
 		}
-		avg = sum / float64(len(fd.Hour))
+		avg = sum / float64(len(fd.Hours))
 	}
 
 	// Iconos
-	iconCond := pickConditionEmoji(opt.Emoji, conditionText)
-	iconMax := em(opt.Emoji, "🔺")
-	iconAvg := em(opt.Emoji, "📊")
-	iconMin := em(opt.Emoji, "🔻")
-	iconWind := em(opt.Emoji, "💨")
-	iconHum := em(opt.Emoji, "💧")
-	iconSunrise := em(opt.Emoji, "🌅")
-	iconSunset := em(opt.Emoji, "🌇")
-
-	// Resumen del día
-
-	_, _ = fmt.Fprintf(out, "%s%s %s\n", iconCond+th.label("Hoy:"), "", th.value(conditionText))
+	iconCond := conditionIcon(opt, cond)
+	iconMax := em(opt.decorate(), "🔺")
+	iconAvg := em(opt.decorate(), "📊")
+	iconMin := em(opt.decorate(), "🔻")
+	iconWind := em(opt.decorate(), "💨")
+	iconHum := em(opt.decorate(), "💧")
+	iconUV := em(opt.decorate(), "🔆")
+	iconRain := em(opt.decorate(), "🌧️")
+	iconSnow := em(opt.decorate(), "❄️")
+	iconSunrise := em(opt.decorate(), "🌅")
+	iconSunset := em(opt.decorate(), "🌇")
+
+	// Resumen del día: todos los valores son los del propio día, no los actuales
+
+	_, _ = fmt.Fprintf(out, "%s%s %s\n", iconCond+th.label(l.Weekday(headerTime)+":"), "", th.value(cond.Text))
 	_, _ = fmt.Fprintf(out, "  %s%s  %s  %s%s  %s  %s%s  %s\n",
-		iconMax, th.label("max:"), fmtTemp(th, fd.Day.MaxtempC),
-		iconAvg, th.label("avg:"), fmtTemp(th, avg),
-		iconMin, th.label("min:"), fmtTemp(th, fd.Day.MintempC),
+		iconMax, th.label(l.Sprintf("max:")), fmtTemp(th, u, fd.MaxTempC),
+		iconAvg, th.label(l.Sprintf("avg:")), fmtTemp(th, u, avg),
+		iconMin, th.label(l.Sprintf("min:")), fmtTemp(th, u, fd.MinTempC),
 	)
-	_, _ = fmt.Fprintf(out, "  %s%s %s  %s%s %s\n",
-		iconWind, th.label("viento:"), th.value(fmt.Sprintf("%.0f km/h", w.Current.WindKph)),
-		iconHum, th.label("humedad:"), th.value(fmt.Sprintf("%d%%", w.Current.Humidity)),
+	_, _ = fmt.Fprintf(out, "  %s%s %s  %s%s %s  %s%s %s\n",
+		iconWind, th.label(l.Sprintf("max wind:")), th.value(u.Wind(fd.MaxWindKph)),
+		iconHum, th.label(l.Sprintf("humidity:")), th.value(l.Sprintf("%d%%", fd.AvgHumidity)),
+		iconUV, th.label("UV:"), th.value(l.Sprintf("%.0f", fd.UV)),
+	)
+	_, _ = fmt.Fprintf(out, "  %s%s %s %s  %s%s %s %s\n",
+		iconRain, th.label(l.Sprintf("rain:")), th.value(u.Precip(fd.TotalPrecipMm)), fmtPercent(th, float64(fd.ChanceOfRain)),
+		iconSnow, th.label(l.Sprintf("snow:")), th.value(u.Snow(fd.TotalSnowCm)), fmtPercent(th, float64(fd.ChanceOfSnow)),
 	)
 	_, _ = fmt.Fprintf(out, "  %s%s %s  %s%s %s\n\n",
-		iconSunrise, th.label("amanecer:"), th.value(fd.Astro.Sunrise),
-		iconSunset, th.label("atardecer:"), th.value(fd.Astro.Sunset),
+		iconSunrise, th.label(l.Sprintf("sunrise:")), th.value(fd.Astro.Sunrise),
+		iconSunset, th.label(l.Sprintf("sunset:")), th.value(fd.Astro.Sunset),
 	)
 
-	// Horas
-	umbrella := em(opt.Emoji, "☔️")
-	for _, hour := range fd.Hour {
-		tm := time.Unix(int64(hour.TimeEpoch), 0).Local()
+	// Horas; con --chart ya se ven en la gráfica
+	if opt.Chart != NoChart {
+		return nil
+	}
+	umbrella := em(opt.decorate(), "☔️")
+	for _, hour := range fd.Hours {
+		tm := hour.Time.In(tz)
 		hhmm := tm.Format("15:04")
-		condEm := pickConditionEmoji(opt.Emoji, hour.Condition.Text)
+		condEm := conditionIcon(opt, hour.Condition)
 
 		// Construimos cada parte ya coloreada
 		timePart := th.dim(hhmm)
 		condPart := condEm + th.value(hour.Condition.Text)
-		tempPart := fmtTemp(th, hour.TempC)
+		tempPart := fmtTemp(th, u, hour.TempC)
 		rainPart := umbrella + fmtPercent(th, hour.ChanceOfRain)
 
 		_, _ = fmt.Fprintf(out, "%s %s - %s, %s\n", timePart, condPart, tempPart, rainPart)
@@ -2,9 +2,10 @@ package render
 
 import (
 	"bytes"
-	"encoding/json"
-	"fmt"
-	"mruiz/cliWeather/internal/api/weatherapi"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/icons"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
 	"strings"
 	"testing"
 	"time"
@@ -27,48 +28,27 @@ func containsAnyEmoji(s string) bool {
 }
 
 func TestRender_NoColorNoEmoji(t *testing.T) {
-	now := time.Now().Unix()
-
-	var w weatherapi.Weather
-	// Construimos el objeto con JSON para evitar incompatibilidades de structs anónimos.
-	payload := fmt.Sprintf(`{
-      "location": {"name":"Vigo","country":"Spain"},
-      "current": {
-        "last_updated_epoch": %d,
-        "temp_c": 18,
-        "condition": {"text":"Parcialmente nublado"},
-        "wind_kph": 10,
-        "humidity": 55
-      },
-      "forecast": {
-        "forecastday": [{
-          "day": {
-            "maxtemp_c": 20,
-            "mintemp_c": 15,
-            "daily_chance_of_rain": 30,
-            "daily_will_it_rain": 0
-          },
-          "astro": {"sunrise":"08:00 AM","sunset":"08:00 PM"},
-          "hour": [
-            {
-              "time_epoch": %d,
-              "temp_c": 17.5,
-              "chance_of_rain": 10,
-              "condition": {"text":"Despejado"}
-            },
-            {
-              "time_epoch": %d,
-              "temp_c": 19.0,
-              "chance_of_rain": 20,
-              "condition": {"text":"Soleado"}
-            }
-          ]
-        }]
-      }
-    }`, now, now, now+3600)
-
-	if err := json.Unmarshal([]byte(payload), &w); err != nil {
-		t.Fatalf("unmarshal: %v", err)
+	now := time.Now()
+
+	w := weather.Forecast{
+		Location: weather.Location{Name: "Vigo", Country: "Spain"},
+		Current: weather.Current{
+			Time:      now,
+			TempC:     18,
+			Condition: weather.Condition{Text: "Parcialmente nublado"},
+			WindKph:   10,
+			Humidity:  55,
+		},
+		Days: []weather.Day{{
+			MaxTempC:     20,
+			MinTempC:     15,
+			ChanceOfRain: 30,
+			Astro:        weather.Astro{Sunrise: "08:00 AM", Sunset: "08:00 PM"},
+			Hours: []weather.Hour{
+				{Time: now, TempC: 17.5, ChanceOfRain: 10, Condition: weather.Condition{Text: "Despejado"}},
+				{Time: now.Add(time.Hour), TempC: 19.0, ChanceOfRain: 20, Condition: weather.Condition{Text: "Soleado"}},
+			},
+		}},
 	}
 
 	var buf bytes.Buffer
@@ -98,10 +78,10 @@ func TestRender_NoColorNoEmoji(t *testing.T) {
 	for _, want := range []string{
 		"¡Buen día", // encabezado
 		"Fecha:",
-		"Hoy:",
+		defaultLocale.Weekday(now) + ":",
 		"max:",
 		"min:",
-		"viento:",
+		"viento max:",
 		"humedad:",
 		"amanecer:",
 		"atardecer:",
@@ -113,48 +93,27 @@ func TestRender_NoColorNoEmoji(t *testing.T) {
 }
 
 func TestRender_ColorEmoji(t *testing.T) {
-	now := time.Now().Unix()
-
-	var w weatherapi.Weather
-	// Usamos "Soleado" para forzar ☀️ en pickConditionEmoji
-	payload := fmt.Sprintf(`{
-      "location": {"name":"Madrid","country":"Spain"},
-      "current": {
-        "last_updated_epoch": %d,
-        "temp_c": 25,
-        "condition": {"text":"Soleado"},
-        "wind_kph": 4,
-        "humidity": 34
-      },
-      "forecast": {
-        "forecastday": [{
-          "day": {
-            "maxtemp_c": 26,
-            "mintemp_c": 12,
-            "daily_chance_of_rain": 0,
-            "daily_will_it_rain": 0
-          },
-          "astro": {"sunrise":"08:06 AM","sunset":"08:05 PM"},
-          "hour": [
-            {
-              "time_epoch": %d,
-              "temp_c": 16,
-              "chance_of_rain": 0,
-              "condition": {"text":"Despejado"}
-            },
-            {
-              "time_epoch": %d,
-              "temp_c": 19,
-              "chance_of_rain": 5,
-              "condition": {"text":"Soleado"}
-            }
-          ]
-        }]
-      }
-    }`, now, now, now+3600)
-
-	if err := json.Unmarshal([]byte(payload), &w); err != nil {
-		t.Fatalf("unmarshal: %v", err)
+	now := time.Now()
+
+	// El código 1000 de día fuerza ☀️ sea cual sea el texto
+	w := weather.Forecast{
+		Location: weather.Location{Name: "Madrid", Country: "Spain"},
+		Current: weather.Current{
+			Time:      now,
+			TempC:     25,
+			Condition: weather.Condition{Text: "Soleado", Code: 1000, IsDay: true},
+			WindKph:   4,
+			Humidity:  34,
+		},
+		Days: []weather.Day{{
+			MaxTempC: 26,
+			MinTempC: 12,
+			Astro:    weather.Astro{Sunrise: "08:06 AM", Sunset: "08:05 PM"},
+			Hours: []weather.Hour{
+				{Time: now, TempC: 16, ChanceOfRain: 0, Condition: weather.Condition{Text: "Despejado", Code: 1000}},
+				{Time: now.Add(time.Hour), TempC: 19, ChanceOfRain: 5, Condition: weather.Condition{Text: "Soleado", Code: 1000, IsDay: true}},
+			},
+		}},
 	}
 
 	var buf bytes.Buffer
@@ -167,7 +126,7 @@ func TestRender_ColorEmoji(t *testing.T) {
 
 	out := buf.String()
 
-	// Debe haber ANSI y al menos un emoji (☀️ por "Soleado")
+	// Debe haber ANSI y al menos un emoji (☀️ por el código 1000)
 	if !containsANSI(out) {
 		t.Fatalf("expected ANSI sequences (color) in output, got:\n%s", out)
 	}
@@ -182,7 +141,7 @@ func TestRender_ColorEmoji(t *testing.T) {
 
 	// Algunas comprobaciones de contenido
 	for _, want := range []string{
-		"Hoy:",
+		defaultLocale.Weekday(now) + ":",
 		"max:",
 		"min:",
 	} {
@@ -191,3 +150,192 @@ func TestRender_ColorEmoji(t *testing.T) {
 		}
 	}
 }
+
+func TestRender_ImperialUnits(t *testing.T) {
+	now := time.Now()
+	w := weather.Forecast{
+		Location: weather.Location{Name: "Boston", Country: "USA"},
+		Current:  weather.Current{Time: now},
+		Days: []weather.Day{{
+			MaxTempC:   31,
+			MinTempC:   5,
+			MaxWindKph: 16.09344,
+			Hours: []weather.Hour{
+				{Time: now, TempC: 20, Condition: weather.Condition{Text: "Sunny"}},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	opt := Options{Color: true, Units: units.Imperial}
+	if err := RenderDay(&w, 0, 1, &buf, opt); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if strings.Contains(out, "°C") || strings.Contains(out, "km/h") {
+		t.Fatalf("expected no metric units, got:\n%s", out)
+	}
+	// Los umbrales de color no dependen de la unidad: 31 °C sigue siendo "caliente"
+	for _, want := range []string{"\x1b[31m88°F", "\x1b[34m41°F", "68°F", "10 mph"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected output to contain %q, got:\n%q", want, out)
+		}
+	}
+}
+
+// Regresión: cada bloque de día debe mostrar sus propios valores, no repetir
+// las condiciones actuales en todos los días.
+func TestRender_MultiDayUsesDailyValues(t *testing.T) {
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	w := weather.Forecast{
+		Location: weather.Location{Name: "Vigo", Country: "Spain"},
+		Current:  weather.Current{Time: monday, WindKph: 99, Humidity: 11},
+		Days: []weather.Day{
+			{
+				Date: monday, MaxTempC: 20, MinTempC: 14,
+				MaxWindKph: 20.2, AvgHumidity: 82, TotalPrecipMm: 0.1, ChanceOfRain: 87,
+				TotalSnowCm: 0, ChanceOfSnow: 0, UV: 5,
+				Condition: weather.Condition{Text: "Patchy rain nearby"},
+			},
+			{
+				Date: tuesday, MaxTempC: 3, MinTempC: -2,
+				MaxWindKph: 41, AvgHumidity: 65, TotalPrecipMm: 4.2, ChanceOfRain: 10,
+				TotalSnowCm: 3.5, ChanceOfSnow: 70, UV: 1,
+				Condition: weather.Condition{Text: "Light snow"},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderAll(&w, &buf, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	blocks := strings.Split(out, "===")
+	if len(blocks) != 3 {
+		t.Fatalf("expected two day blocks, got:\n%s", out)
+	}
+	want := [][]string{
+		{"lunes: Patchy rain nearby", "viento max: 20 km/h", "humedad: 82%", "UV: 5", "lluvia: 0,1 mm 87%", "nieve: 0,0 cm 0%"},
+		{"martes: Light snow", "viento max: 41 km/h", "humedad: 65%", "UV: 1", "lluvia: 4,2 mm 10%", "nieve: 3,5 cm 70%"},
+	}
+	for i, block := range blocks[1:] {
+		for _, s := range want[i] {
+			if !strings.Contains(block, s) {
+				t.Errorf("day %d: expected %q in block:\n%s", i, s, block)
+			}
+		}
+		if strings.Contains(block, "99 km/h") || strings.Contains(block, "11%") {
+			t.Errorf("day %d: current conditions leaked into day block:\n%s", i, block)
+		}
+	}
+}
+
+func TestRender_Localized(t *testing.T) {
+	monday := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+	w := weather.Forecast{
+		Location: weather.Location{Name: "Vigo", Country: "Spain"},
+		Current:  weather.Current{Time: monday},
+		Days: []weather.Day{{
+			Date: monday, MaxTempC: 20, MinTempC: 14, TotalPrecipMm: 1.5,
+			Astro:     weather.Astro{Sunrise: "08:41 AM", Sunset: "07:33 PM"},
+			Condition: weather.Condition{Text: "Rain"},
+		}},
+	}
+
+	cases := map[string][]string{
+		"en": {"Good day!", "Date: Mon 19 Oct 2026", "Mon 19 Oct 2026 (day 1/1)", "Monday: Rain", "max wind:", "rain: 1.5 mm", "sunrise:"},
+		"es": {"¡Buen día!", "Fecha: lun 19 oct 2026", "lun 19 oct 2026 (día 1/1)", "lunes: Rain", "viento max:", "lluvia: 1,5 mm", "amanecer:"},
+		"fr": {"Bonjour !", "Date: lun 19 oct 2026", "(jour 1/1)", "lundi: Rain", "vent max:", "pluie: 1,5 mm", "lever:"},
+		"gl": {"Bo día!", "Data: lun 19 out 2026", "(día 1/1)", "luns: Rain", "vento máx:", "choiva: 1,5 mm", "amencer:"},
+		"pt": {"Bom dia!", "Data: seg 19 out 2026", "(dia 1/1)", "segunda-feira: Rain", "vento máx:", "chuva: 1,5 mm", "nascer do sol:"},
+	}
+	for lang, want := range cases {
+		var buf bytes.Buffer
+		opt := Options{Locale: i18n.New(lang)}
+		RenderHeader(&w, &buf, opt)
+		if err := RenderAll(&w, &buf, opt); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		for _, s := range want {
+			if !strings.Contains(out, s) {
+				t.Errorf("%s: expected %q in output:\n%s", lang, s, out)
+			}
+		}
+	}
+}
+
+func TestRender_IconsFromConditionCode(t *testing.T) {
+	now := time.Date(2026, 10, 19, 3, 0, 0, 0, time.Local)
+	w := weather.Forecast{
+		Days: []weather.Day{{
+			Date: now,
+			Hours: []weather.Hour{
+				// Texto en gallego: el icono sale del código, no del texto
+				{Time: now, Condition: weather.Condition{Text: "Despexado", Code: 1000, IsDay: false}},
+				{Time: now.Add(9 * time.Hour), Condition: weather.Condition{Text: "Chuvia moderada", Code: 1189, IsDay: true}},
+			},
+		}},
+	}
+
+	cases := []struct {
+		opt  Options
+		want []string
+	}{
+		{Options{Emoji: true}, []string{"🌙 Despexado", "🌧️ Chuvia moderada"}},
+		{Options{Emoji: true, Icons: icons.ASCII}, []string{"[moon] Despexado", "[rain] Chuvia moderada"}},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		if err := RenderDay(&w, 0, 1, &buf, c.opt); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		for _, s := range c.want {
+			if !strings.Contains(out, s) {
+				t.Errorf("%s: expected %q in output:\n%s", c.opt.iconSet().Name, s, out)
+			}
+		}
+		if c.opt.Icons == icons.ASCII && containsAnyEmoji(out) {
+			t.Errorf("ascii icons must not print emoji:\n%s", out)
+		}
+	}
+}
+
+// Al oeste de la localización el día no puede pasar a ser el anterior: la
+// fecha es la de la localización y las horas, en su zona
+func TestRenderDay_LocationDateWestOfLocation(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip(err)
+	}
+	time.Local = la
+
+	madrid, _ := time.LoadLocation("Europe/Madrid")
+	date := time.Date(2026, 10, 19, 0, 0, 0, 0, madrid)
+	w := weather.Forecast{
+		Location: weather.Location{Name: "Vigo", Country: "Spain", TimeZone: "Europe/Madrid"},
+		Days: []weather.Day{{
+			Date:  date,
+			Hours: []weather.Hour{{Time: date.Add(12 * time.Hour).UTC(), TempC: 19, Condition: weather.Condition{Text: "Sunny"}}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderDay(&w, 0, 1, &buf, Options{Locale: i18n.New("en")}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Mon 19 Oct 2026 (day 1/1)", "Monday:", "12:00 Sunny"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
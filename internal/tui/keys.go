@@ -0,0 +1,80 @@
+package tui
+
+// Key es una tecla ya interpretada de la entrada en modo raw
+type Key int
+
+const (
+	KeyNone Key = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyTab
+	KeyBackTab
+	KeyUnits
+	KeyRefresh
+	KeyQuit
+)
+
+// escapes son las secuencias de las teclas especiales (xterm y VT100)
+var escapes = map[string]Key{
+	"\x1b[A": KeyUp, "\x1bOA": KeyUp,
+	"\x1b[B": KeyDown, "\x1bOB": KeyDown,
+	"\x1b[C": KeyRight, "\x1bOC": KeyRight,
+	"\x1b[D": KeyLeft, "\x1bOD": KeyLeft,
+	"\x1b[5~": KeyPageUp, "\x1b[6~": KeyPageDown,
+	"\x1b[H": KeyHome, "\x1b[1~": KeyHome, "\x1bOH": KeyHome,
+	"\x1b[F": KeyEnd, "\x1b[4~": KeyEnd, "\x1bOF": KeyEnd,
+	"\x1b[Z": KeyBackTab,
+}
+
+// letters son los atajos de una sola tecla, incluidos los de vi
+var letters = map[byte]Key{
+	'k': KeyUp, 'j': KeyDown, 'h': KeyLeft, 'l': KeyRight,
+	' ': KeyPageDown, 'b': KeyPageUp, 'g': KeyHome, 'G': KeyEnd,
+	'\t': KeyTab, 'u': KeyUnits, 'r': KeyRefresh,
+	'q': KeyQuit, 'Q': KeyQuit, 0x03: KeyQuit, // Ctrl-C
+}
+
+// ParseKeys traduce lo leído de la terminal a teclas. Una lectura puede
+// traer varias teclas seguidas; lo que no se reconoce se descarta.
+func ParseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		if b[0] == 0x1b {
+			if len(b) == 1 {
+				keys = append(keys, KeyQuit) // Esc suelto
+				break
+			}
+			n := escapeLen(b)
+			if k, ok := escapes[string(b[:n])]; ok {
+				keys = append(keys, k)
+			}
+			b = b[n:]
+			continue
+		}
+		if k, ok := letters[b[0]]; ok {
+			keys = append(keys, k)
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// escapeLen mide una secuencia que empieza por ESC: "ESC [ parámetros
+// final" o "ESC O letra"
+func escapeLen(b []byte) int {
+	if len(b) < 2 || (b[1] != '[' && b[1] != 'O') {
+		return 1
+	}
+	for i := 2; i < len(b); i++ {
+		if b[i] >= 0x40 && b[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(b)
+}
@@ -0,0 +1,304 @@
+// Package tui implementa la interfaz interactiva de `cliweather tui`: lista
+// de localizaciones, pestañas por día, tabla horaria desplazable y un panel
+// con gráficas de temperatura, lluvia y viento. El estado y el dibujo
+// (Model) no dependen de la terminal, así que se prueban sin ella; Run se
+// encarga del modo raw y de la entrada.
+package tui
+
+import (
+	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/units"
+	"mruiz/cliWeather/internal/weather"
+	"strings"
+	"time"
+)
+
+// unitSystems es el ciclo de la tecla u
+var unitSystems = []string{"metric", "imperial", "uk"}
+
+const (
+	sideWidth   = 20 // ancho de la lista de localizaciones
+	chartHeight = 4  // separador y tres gráficas
+)
+
+// Model es el estado de la TUI
+type Model struct {
+	Locations []string
+	opt       render.Options
+	unit      int // índice en unitSystems
+
+	loc, day, scroll int
+	width, height    int
+
+	data    map[string]*weather.Forecast
+	updated map[string]time.Time
+	errs    map[string]error
+	loading map[string]bool
+}
+
+// New crea el modelo para las localizaciones dadas. unitName es el sistema
+// inicial (metric, imperial o uk).
+func New(locations []string, unitName string, opt render.Options) *Model {
+	m := &Model{
+		Locations: locations,
+		opt:       opt,
+		width:     80,
+		height:    24,
+		data:      map[string]*weather.Forecast{},
+		updated:   map[string]time.Time{},
+		errs:      map[string]error{},
+		loading:   map[string]bool{},
+	}
+	for i, name := range unitSystems {
+		if name == unitName {
+			m.unit = i
+		}
+	}
+	return m
+}
+
+// Resize fija el tamaño de la terminal
+func (m *Model) Resize(width, height int) {
+	m.width, m.height = width, height
+	m.clampScroll()
+}
+
+// Current devuelve la consulta de la localización seleccionada
+func (m *Model) Current() string {
+	return m.Locations[m.loc]
+}
+
+// NeedsFetch indica si la localización seleccionada aún no tiene datos ni
+// una petición en curso
+func (m *Model) NeedsFetch() bool {
+	q := m.Current()
+	return m.data[q] == nil && m.errs[q] == nil && !m.loading[q]
+}
+
+// SetLoading marca que hay una petición en curso para q
+func (m *Model) SetLoading(q string) {
+	m.loading[q] = true
+}
+
+// SetResult guarda el resultado de una petición. Con error se conservan los
+// datos anteriores. Los primeros datos de una localización colocan la tabla
+// en la hora actual.
+func (m *Model) SetResult(q string, f *weather.Forecast, err error, now time.Time) {
+	m.loading[q] = false
+	m.errs[q] = err
+	if err != nil {
+		return
+	}
+	first := m.data[q] == nil
+	m.data[q], m.updated[q] = f, now
+	if first && q == m.Current() {
+		m.day, m.scroll = 0, 0
+		if len(f.Days) > 0 {
+			for i, h := range f.Days[0].Hours {
+				if !h.Time.After(now) {
+					m.scroll = i
+				}
+			}
+		}
+	}
+	m.clampScroll()
+}
+
+// HandleKey aplica una tecla. Devuelve la acción que debe hacer el bucle
+// de Run: KeyRefresh para volver a pedir la localización actual, KeyQuit
+// para salir o KeyNone.
+func (m *Model) HandleKey(k Key) Key {
+	switch k {
+	case KeyQuit, KeyRefresh:
+		return k
+	case KeyUp:
+		m.scroll--
+	case KeyDown:
+		m.scroll++
+	case KeyPageUp:
+		m.scroll -= m.tableRows()
+	case KeyPageDown:
+		m.scroll += m.tableRows()
+	case KeyHome:
+		m.scroll = 0
+	case KeyEnd:
+		m.scroll = len(m.hours())
+	case KeyLeft:
+		m.day = max(m.day-1, 0)
+	case KeyRight:
+		if f := m.data[m.Current()]; f != nil {
+			m.day = min(m.day+1, len(f.Days)-1)
+		}
+	case KeyTab, KeyBackTab:
+		step := 1
+		if k == KeyBackTab {
+			step = len(m.Locations) - 1
+		}
+		m.loc = (m.loc + step) % len(m.Locations)
+		m.day, m.scroll = 0, 0
+	case KeyUnits:
+		m.unit = (m.unit + 1) % len(unitSystems)
+		if u, err := units.Parse(unitSystems[m.unit]); err == nil {
+			m.opt.Units = u
+		}
+	}
+	m.clampScroll()
+	return KeyNone
+}
+
+// hours devuelve las horas del día seleccionado
+func (m *Model) hours() []weather.Hour {
+	f := m.data[m.Current()]
+	if f == nil || m.day >= len(f.Days) {
+		return nil
+	}
+	return f.Days[m.day].Hours
+}
+
+// tableRows es cuántas horas caben en la tabla: el alto menos la barra de
+// título, las pestañas, la cabecera de la tabla, las gráficas y el pie
+func (m *Model) tableRows() int {
+	return max(m.height-4-chartHeight, 1)
+}
+
+func (m *Model) clampScroll() {
+	m.scroll = max(min(m.scroll, len(m.hours())-m.tableRows()), 0)
+}
+
+// View dibuja la pantalla completa, una cadena por línea de la terminal
+func (m *Model) View() []string {
+	l := m.opt.Locale
+	q := m.Current()
+	f := m.data[q]
+
+	title := " cliweather · " + q
+	if f != nil {
+		title = " cliweather · " + f.Location.Name
+		if f.Location.Country != "" {
+			title += ", " + f.Location.Country
+		}
+	}
+	title += " · " + unitSystems[m.unit]
+
+	right := m.rightPanel(f)
+	side := m.sidePanel()
+	body := m.height - 2
+	lines := []string{m.reverse(fit(title, m.width))}
+	for i := range body {
+		s, r := strings.Repeat(" ", sideWidth), ""
+		if i < len(side) {
+			s = side[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		lines = append(lines, s+m.dim("│")+" "+r)
+	}
+
+	status := ""
+	switch {
+	case m.loading[q]:
+		status = l.Sprintf("Loading…")
+	case m.errs[q] != nil && f != nil:
+		status = l.Sprintf("Stale: last update %s", m.updated[q].Format("15:04:05"))
+	case f != nil:
+		status = l.Sprintf("Updated %s", m.updated[q].Format("15:04:05"))
+	}
+	help := l.Sprintf("↑↓ hours  ←→ days  Tab locations  u units  r refresh  q quit")
+	gap := m.width - len([]rune(help)) - len([]rune(status)) - 2
+	lines = append(lines, m.dim(" "+help+strings.Repeat(" ", max(gap, 1))+status))
+	return lines
+}
+
+// sidePanel es la lista de localizaciones con la seleccionada marcada. Todas
+// las líneas ocupan exactamente sideWidth columnas.
+func (m *Model) sidePanel() []string {
+	lines := []string{fit(" "+m.opt.Locale.Sprintf("Locations"), sideWidth), fit("", sideWidth)}
+	for i, q := range m.Locations {
+		mark := "  "
+		switch {
+		case m.loading[q]:
+			mark = " …"
+		case m.errs[q] != nil:
+			mark = " !"
+		}
+		if i != m.loc {
+			lines = append(lines, fit("  "+q, sideWidth-2)+mark)
+			continue
+		}
+		lines = append(lines, m.reverse(fit(" ›"+q, sideWidth-2)+mark))
+	}
+	return lines
+}
+
+// rightPanel son las pestañas de días, la tabla horaria y las gráficas
+func (m *Model) rightPanel(f *weather.Forecast) []string {
+	l := m.opt.Locale
+	q := m.Current()
+	if f == nil {
+		switch {
+		case m.errs[q] != nil:
+			return []string{"", l.Sprintf("error: %s", m.errs[q])}
+		default:
+			return []string{"", l.Sprintf("Loading %s…", q)}
+		}
+	}
+	if len(f.Days) == 0 {
+		return []string{"", l.Sprintf("No forecast available.")}
+	}
+
+	// Pestañas de días
+	var tabs []string
+	for i, d := range f.Days {
+		tab := " " + l.ShortDate(d.Date) + " "
+		if i == m.day {
+			tab = m.reverse(tab)
+		}
+		tabs = append(tabs, tab)
+	}
+	lines := []string{strings.Join(tabs, " ")}
+
+	// Tabla horaria: cabecera fija y filas desde scroll
+	table := render.HourlyTable(f, m.day, m.opt)
+	rows := m.tableRows()
+	if len(table) > 0 {
+		lines = append(lines, table[0])
+		end := min(m.scroll+1+rows, len(table))
+		lines = append(lines, table[m.scroll+1:end]...)
+		for range rows - (end - m.scroll - 1) {
+			lines = append(lines, "")
+		}
+	}
+
+	// Gráficas de temperatura, lluvia y viento del día
+	charts := render.ChartRows(f, m.day, m.width-sideWidth-3, m.opt)
+	if len(charts) > 0 {
+		lines = append(lines, m.dim(strings.Repeat("─", max(m.width-sideWidth-3, 0))))
+		lines = append(lines, charts...)
+	}
+	return lines
+}
+
+// reverse resalta s en vídeo inverso, o lo deja igual sin color
+func (m *Model) reverse(s string) string {
+	if !m.opt.Color {
+		return s
+	}
+	return "\x1b[7m" + s + "\x1b[27m"
+}
+
+func (m *Model) dim(s string) string {
+	if !m.opt.Color {
+		return s
+	}
+	return "\x1b[90m" + s + "\x1b[0m"
+}
+
+// fit recorta o rellena con espacios s (texto sin ANSI) hasta w columnas
+func fit(s string, w int) string {
+	r := []rune(s)
+	if len(r) > w {
+		return string(r[:w])
+	}
+	return s + strings.Repeat(" ", w-len(r))
+}
@@ -0,0 +1,129 @@
+package tui
+
+import (
+	"errors"
+	"mruiz/cliWeather/internal/i18n"
+	"mruiz/cliWeather/internal/render"
+	"mruiz/cliWeather/internal/weather"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sample() *weather.Forecast {
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	f := &weather.Forecast{Location: weather.Location{Name: "Vigo", Country: "Spain", TimeZone: "UTC"}}
+	for d := range 3 {
+		wd := weather.Day{Date: day.AddDate(0, 0, d)}
+		for h := range 24 {
+			wd.Hours = append(wd.Hours, weather.Hour{
+				Time: wd.Date.Add(time.Duration(h) * time.Hour), TempC: float64(10 + h%12), ChanceOfRain: float64(h * 4),
+				Condition: weather.Condition{Text: "Clear", Code: 1000},
+			})
+		}
+		f.Days = append(f.Days, wd)
+	}
+	return f
+}
+
+func newModel() *Model {
+	m := New([]string{"Vigo", "Madrid"}, "metric", render.Options{Locale: i18n.New("en")})
+	m.Resize(100, 20)
+	return m
+}
+
+func TestParseKeys(t *testing.T) {
+	got := ParseKeys([]byte("j\x1b[A\x1b[6~\x1bOCx\tq\x1b[Z\x1b[99;5u\x03"))
+	want := []Key{KeyDown, KeyUp, KeyPageDown, KeyRight, KeyTab, KeyQuit, KeyBackTab, KeyQuit}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseKeys = %v, want %v", got, want)
+	}
+	if got := ParseKeys([]byte("\x1b")); !reflect.DeepEqual(got, []Key{KeyQuit}) {
+		t.Errorf("lone Esc = %v", got)
+	}
+}
+
+func TestModel_Navigation(t *testing.T) {
+	m := newModel()
+	if !m.NeedsFetch() {
+		t.Fatal("first location must need a fetch")
+	}
+	m.SetLoading("Vigo")
+	if m.NeedsFetch() {
+		t.Fatal("a loading location must not be fetched again")
+	}
+
+	// Los primeros datos colocan la tabla en la hora actual (acotada al final)
+	now := sample().Days[0].Hours[5].Time.Add(10 * time.Minute)
+	m.SetResult("Vigo", sample(), nil, now)
+	if m.scroll != 5 {
+		t.Errorf("scroll = %d, want 5", m.scroll)
+	}
+	m.HandleKey(KeyEnd)
+	if rows := m.tableRows(); m.scroll != 24-rows {
+		t.Errorf("End: scroll = %d, want %d", m.scroll, 24-rows)
+	}
+	m.HandleKey(KeyHome)
+	m.HandleKey(KeyUp)
+	if m.scroll != 0 {
+		t.Errorf("scroll must not go below 0: %d", m.scroll)
+	}
+
+	for range 5 {
+		m.HandleKey(KeyRight)
+	}
+	if m.day != 2 {
+		t.Errorf("day = %d, want the last one", m.day)
+	}
+
+	m.HandleKey(KeyTab)
+	if m.Current() != "Madrid" || m.day != 0 || !m.NeedsFetch() {
+		t.Errorf("Tab: current=%s day=%d", m.Current(), m.day)
+	}
+	m.HandleKey(KeyBackTab)
+	if m.Current() != "Vigo" {
+		t.Errorf("BackTab: current=%s", m.Current())
+	}
+
+	if m.HandleKey(KeyRefresh) != KeyRefresh || m.HandleKey(KeyQuit) != KeyQuit {
+		t.Error("refresh and quit must be returned to the loop")
+	}
+}
+
+func TestModel_View(t *testing.T) {
+	m := newModel()
+	m.SetResult("Vigo", sample(), nil, sample().Days[0].Hours[0].Time)
+
+	lines := m.View()
+	if len(lines) != 20 {
+		t.Fatalf("view has %d lines, want the terminal height", len(lines))
+	}
+	screen := strings.Join(lines, "\n")
+	for _, want := range []string{"cliweather · Vigo, Spain · metric", "›Vigo", "Mon 19 Oct", "Temp", "Rain", "00:00", "10°C", "_", "#", "Updated"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("view lacks %q:\n%s", want, screen)
+		}
+	}
+	if strings.Contains(screen, "\x1b[") {
+		t.Error("view without color must not contain ANSI sequences")
+	}
+
+	m.opt.Emoji, m.opt.Color = true, true
+	if screen := strings.Join(m.View(), "\n"); !strings.Contains(screen, "▁") || !strings.Contains(screen, "█") {
+		t.Errorf("charts must use block characters with emoji and color:\n%s", screen)
+	}
+	m.opt.Emoji, m.opt.Color = false, false
+
+	m.HandleKey(KeyUnits)
+	if screen := strings.Join(m.View(), "\n"); !strings.Contains(screen, "50°F") || !strings.Contains(screen, "· imperial") {
+		t.Errorf("u must switch to imperial:\n%s", screen)
+	}
+
+	// Un error tras tener datos los conserva y marca la localización
+	m.SetResult("Vigo", nil, errors.New("boom"), time.Now())
+	screen = strings.Join(m.View(), "\n")
+	if !strings.Contains(screen, "00:00") || !strings.Contains(screen, "Stale") || !strings.Contains(screen, "Vigo             !") {
+		t.Errorf("stale view:\n%s", screen)
+	}
+}
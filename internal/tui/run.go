@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"mruiz/cliWeather/internal/weather"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Fetcher pide la previsión de una consulta. refresh indica que el usuario
+// ha pedido datos nuevos y no valen los de la caché.
+type Fetcher func(ctx context.Context, query string, refresh bool) (*weather.Forecast, error)
+
+// result es la respuesta de una petición lanzada en segundo plano
+type result struct {
+	query string
+	data  *weather.Forecast
+	err   error
+}
+
+// Run pone la terminal en modo raw y en la pantalla alternativa, y atiende
+// teclas, respuestas y cambios de tamaño (resize) hasta que se pulsa q, Esc
+// o Ctrl-C, o se cancela ctx. Las peticiones van en segundo plano, así que
+// la interfaz sigue respondiendo mientras se cargan los datos.
+func Run(ctx context.Context, m *Model, fetch Fetcher, resize <-chan os.Signal) error {
+	in, out := os.Stdin, os.Stdout
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return err
+	}
+	defer term.Restore(int(in.Fd()), state)
+
+	out.WriteString("\x1b[?1049h\x1b[?25l\x1b[?7l")
+	defer out.WriteString("\x1b[?7h\x1b[?25h\x1b[?1049l")
+
+	// done avisa a las goroutines de que Run ha terminado: la que lee stdin
+	// deja de leer en cuanto le llega la siguiente tecla en vez de quedarse
+	// bloqueada para siempre enviando a keys
+	done := make(chan struct{})
+	defer close(done)
+
+	keys := make(chan []Key)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				return
+			}
+			select {
+			case keys <- ParseKeys(buf[:n]):
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	results := make(chan result)
+	load := func(q string, refresh bool) {
+		m.SetLoading(q)
+		go func() {
+			f, err := fetch(ctx, q, refresh)
+			select {
+			case results <- result{q, f, err}:
+			case <-ctx.Done():
+			case <-done:
+			}
+		}()
+	}
+
+	size := func() {
+		if w, h, err := term.GetSize(int(out.Fd())); err == nil {
+			m.Resize(w, h)
+		}
+	}
+	size()
+
+	for {
+		if m.NeedsFetch() {
+			load(m.Current(), false)
+		}
+		draw(out, m.View())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-resize:
+			size()
+		case r := <-results:
+			m.SetResult(r.query, r.data, r.err, time.Now())
+		case ks := <-keys:
+			for _, k := range ks {
+				switch m.HandleKey(k) {
+				case KeyQuit:
+					return nil
+				case KeyRefresh:
+					load(m.Current(), true)
+				}
+			}
+		}
+	}
+}
+
+// draw escribe las líneas desde la esquina superior izquierda de una sola vez
+// para evitar parpadeos. En modo raw el salto de línea necesita \r.
+func draw(out *os.File, lines []string) {
+	var buf bytes.Buffer
+	buf.WriteString("\x1b[H")
+	buf.WriteString(strings.Join(lines, "\x1b[K\r\n"))
+	buf.WriteString("\x1b[K\x1b[J")
+	_, _ = out.Write(buf.Bytes())
+}
@@ -0,0 +1,281 @@
+// Package units convierte y formatea magnitudes desde las unidades métricas
+// del modelo (°C, km/h, hPa, mm, cm, km) al sistema elegido por el usuario.
+package units
+
+import (
+	"fmt"
+	"strings"
+)
+
+// El valor cero de cada tipo es la unidad métrica, de forma que un System
+// vacío equivale a Metric.
+
+type TempUnit int
+
+const (
+	Celsius TempUnit = iota
+	Fahrenheit
+)
+
+type WindUnit int
+
+const (
+	KmH WindUnit = iota
+	Mph
+	MetersPerSecond
+	Knots
+	Beaufort
+)
+
+type PressureUnit int
+
+const (
+	HPa PressureUnit = iota
+	InHg
+)
+
+type PrecipUnit int
+
+const (
+	Millimeters PrecipUnit = iota
+	Inches
+)
+
+// System agrupa las unidades con las que se muestra cada magnitud
+type System struct {
+	TempUnit     TempUnit
+	WindUnit     WindUnit
+	PressureUnit PressureUnit
+	PrecipUnit   PrecipUnit
+
+	// Printer formatea los valores con los separadores del idioma; si es
+	// nil se usa fmt (punto decimal)
+	Printer Printer
+}
+
+// Printer formatea como fmt.Sprintf pero según un idioma
+type Printer interface {
+	Sprintf(format string, a ...any) string
+}
+
+// WithPrinter devuelve una copia de s que formatea los valores con p
+func (s System) WithPrinter(p Printer) System {
+	s.Printer = p
+	return s
+}
+
+func (s System) sprintf(format string, a ...any) string {
+	if s.Printer == nil {
+		return fmt.Sprintf(format, a...)
+	}
+	return s.Printer.Sprintf(format, a...)
+}
+
+var (
+	Metric   = System{TempUnit: Celsius, WindUnit: KmH, PressureUnit: HPa, PrecipUnit: Millimeters}
+	Imperial = System{TempUnit: Fahrenheit, WindUnit: Mph, PressureUnit: InHg, PrecipUnit: Inches}
+	// UK: temperatura y presión métricas, viento en mph
+	UK = System{TempUnit: Celsius, WindUnit: Mph, PressureUnit: HPa, PrecipUnit: Millimeters}
+)
+
+var systems = map[string]System{
+	"metric":   Metric,
+	"imperial": Imperial,
+	"uk":       UK,
+}
+
+var windUnits = map[string]WindUnit{
+	"kmh":      KmH,
+	"km/h":     KmH,
+	"mph":      Mph,
+	"ms":       MetersPerSecond,
+	"m/s":      MetersPerSecond,
+	"kn":       Knots,
+	"knots":    Knots,
+	"bft":      Beaufort,
+	"beaufort": Beaufort,
+}
+
+// Parse devuelve el sistema con ese nombre (metric, imperial, uk)
+func Parse(name string) (System, error) {
+	s, ok := systems[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return System{}, fmt.Errorf("unknown unit system %q (expected metric, imperial or uk)", name)
+	}
+	return s, nil
+}
+
+// ParseWind devuelve la unidad de viento con ese nombre
+func ParseWind(name string) (WindUnit, error) {
+	u, ok := windUnits[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("unknown wind unit %q (expected kmh, mph, ms, knots or beaufort)", name)
+	}
+	return u, nil
+}
+
+// ======= Temperatura =======
+
+func (s System) TempValue(c float64) float64 {
+	if s.TempUnit == Fahrenheit {
+		return c*9/5 + 32
+	}
+	return c
+}
+
+func (s System) TempSymbol() string {
+	if s.TempUnit == Fahrenheit {
+		return "°F"
+	}
+	return "°C"
+}
+
+// Temp formatea una temperatura en °C, p. ej. "16°C" o "61°F"
+func (s System) Temp(c float64) string {
+	return s.sprintf("%.0f%s", s.TempValue(c), s.TempSymbol())
+}
+
+// ======= Viento =======
+
+func (s System) WindValue(kph float64) float64 {
+	switch s.WindUnit {
+	case Mph:
+		return kph / 1.609344
+	case MetersPerSecond:
+		return kph / 3.6
+	case Knots:
+		return kph / 1.852
+	case Beaufort:
+		return float64(BeaufortScale(kph))
+	default:
+		return kph
+	}
+}
+
+func (s System) WindSymbol() string {
+	switch s.WindUnit {
+	case Mph:
+		return "mph"
+	case MetersPerSecond:
+		return "m/s"
+	case Knots:
+		return "kn"
+	case Beaufort:
+		return "Bft"
+	default:
+		return "km/h"
+	}
+}
+
+// Wind formatea una velocidad en km/h, p. ej. "7 km/h", "2.0 m/s" o "Bft 2"
+func (s System) Wind(kph float64) string {
+	switch s.WindUnit {
+	case Beaufort:
+		return s.sprintf("Bft %d", BeaufortScale(kph))
+	case MetersPerSecond:
+		return s.sprintf("%.1f m/s", s.WindValue(kph))
+	default:
+		return s.sprintf("%.0f %s", s.WindValue(kph), s.WindSymbol())
+	}
+}
+
+// beaufortLimits son los límites superiores (km/h) de cada grado 0..11
+var beaufortLimits = []float64{1, 5, 11, 19, 28, 38, 49, 61, 74, 88, 102, 117}
+
+// BeaufortScale devuelve el grado de la escala de Beaufort (0..12)
+func BeaufortScale(kph float64) int {
+	for i, limit := range beaufortLimits {
+		if kph <= limit {
+			return i
+		}
+	}
+	return 12
+}
+
+// ======= Presión =======
+
+func (s System) PressureValue(mb float64) float64 {
+	if s.PressureUnit == InHg {
+		return mb * 0.0295299830714
+	}
+	return mb
+}
+
+func (s System) PressureSymbol() string {
+	if s.PressureUnit == InHg {
+		return "inHg"
+	}
+	return "hPa"
+}
+
+// Pressure formatea una presión en hPa, p. ej. "1023 hPa" o "30.21 inHg"
+func (s System) Pressure(mb float64) string {
+	if s.PressureUnit == InHg {
+		return s.sprintf("%.2f inHg", s.PressureValue(mb))
+	}
+	return s.sprintf("%.0f hPa", mb)
+}
+
+// ======= Precipitación, nieve y distancia =======
+
+func (s System) PrecipValue(mm float64) float64 {
+	if s.PrecipUnit == Inches {
+		return mm / 25.4
+	}
+	return mm
+}
+
+func (s System) PrecipSymbol() string {
+	if s.PrecipUnit == Inches {
+		return "in"
+	}
+	return "mm"
+}
+
+// Precip formatea una precipitación en mm, p. ej. "1.2 mm" o "0.05 in"
+func (s System) Precip(mm float64) string {
+	if s.PrecipUnit == Inches {
+		return s.sprintf("%.2f in", s.PrecipValue(mm))
+	}
+	return s.sprintf("%.1f mm", mm)
+}
+
+func (s System) SnowValue(cm float64) float64 {
+	if s.PrecipUnit == Inches {
+		return cm / 2.54
+	}
+	return cm
+}
+
+func (s System) SnowSymbol() string {
+	if s.PrecipUnit == Inches {
+		return "in"
+	}
+	return "cm"
+}
+
+// Snow formatea un espesor de nieve en cm ("3.0 cm" o "1.2 in")
+func (s System) Snow(cm float64) string {
+	return s.sprintf("%.1f %s", s.SnowValue(cm), s.SnowSymbol())
+}
+
+// DistanceValue convierte una distancia en km; va con la unidad de
+// precipitación, que es la que distingue los sistemas imperiales.
+func (s System) DistanceValue(km float64) float64 {
+	if s.PrecipUnit == Inches {
+		return km / 1.609344
+	}
+	return km
+}
+
+func (s System) DistanceSymbol() string {
+	if s.PrecipUnit == Inches {
+		return "mi"
+	}
+	return "km"
+}
+
+// Distance formatea una distancia en km ("0.3 km" o "0.2 mi")
+func (s System) Distance(km float64) string {
+	return s.sprintf("%.1f %s", s.DistanceValue(km), s.DistanceSymbol())
+}
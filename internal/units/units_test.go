@@ -0,0 +1,52 @@
+package units
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	for name, want := range map[string]System{"metric": Metric, "Imperial": Imperial, " uk ": UK} {
+		got, err := Parse(name)
+		if err != nil || got != want {
+			t.Errorf("Parse(%q) = %+v, %v", name, got, err)
+		}
+	}
+	if _, err := Parse("kelvin"); err == nil {
+		t.Error("expected error for unknown system")
+	}
+	if u, err := ParseWind("knots"); err != nil || u != Knots {
+		t.Errorf("ParseWind(knots) = %v, %v", u, err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := []struct {
+		got, want string
+	}{
+		{Metric.Temp(16.1), "16°C"},
+		{Imperial.Temp(16.1), "61°F"},
+		{Metric.Wind(7.2), "7 km/h"},
+		{Imperial.Wind(16.09344), "10 mph"},
+		{System{WindUnit: MetersPerSecond}.Wind(7.2), "2.0 m/s"},
+		{System{WindUnit: Knots}.Wind(18.52), "10 kn"},
+		{System{WindUnit: Beaufort}.Wind(20.2), "Bft 4"},
+		{Metric.Pressure(1023), "1023 hPa"},
+		{Imperial.Pressure(1023), "30.21 inHg"},
+		{Metric.Precip(1.24), "1.2 mm"},
+		{Imperial.Precip(25.4), "1.00 in"},
+		{Imperial.Distance(16.09344), "10.0 mi"},
+		{UK.Temp(20), "20°C"},
+		{UK.Wind(16.09344), "10 mph"},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Errorf("got %q, want %q", tc.got, tc.want)
+		}
+	}
+}
+
+func TestBeaufortScale(t *testing.T) {
+	for kph, want := range map[float64]int{0: 0, 3: 1, 30: 5, 62: 8, 118: 12} {
+		if got := BeaufortScale(kph); got != want {
+			t.Errorf("BeaufortScale(%v) = %d, want %d", kph, got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package weather
+
+// conditionTexts son los textos en inglés de WeatherAPI para cada código
+// ([0] de día, [1] de noche). Sirven a los proveedores que solo devuelven
+// un código numérico.
+var conditionTexts = map[int][2]string{
+	1000: {"Sunny", "Clear"},
+	1003: {"Partly cloudy", "Partly cloudy"},
+	1006: {"Cloudy", "Cloudy"},
+	1009: {"Overcast", "Overcast"},
+	1030: {"Mist", "Mist"},
+	1063: {"Patchy rain nearby", "Patchy rain nearby"},
+	1066: {"Patchy snow nearby", "Patchy snow nearby"},
+	1069: {"Patchy sleet nearby", "Patchy sleet nearby"},
+	1072: {"Patchy freezing drizzle nearby", "Patchy freezing drizzle nearby"},
+	1087: {"Thundery outbreaks in nearby", "Thundery outbreaks in nearby"},
+	1114: {"Blowing snow", "Blowing snow"},
+	1117: {"Blizzard", "Blizzard"},
+	1135: {"Fog", "Fog"},
+	1147: {"Freezing fog", "Freezing fog"},
+	1150: {"Patchy light drizzle", "Patchy light drizzle"},
+	1153: {"Light drizzle", "Light drizzle"},
+	1168: {"Freezing drizzle", "Freezing drizzle"},
+	1171: {"Heavy freezing drizzle", "Heavy freezing drizzle"},
+	1180: {"Patchy light rain", "Patchy light rain"},
+	1183: {"Light rain", "Light rain"},
+	1186: {"Moderate rain at times", "Moderate rain at times"},
+	1189: {"Moderate rain", "Moderate rain"},
+	1192: {"Heavy rain at times", "Heavy rain at times"},
+	1195: {"Heavy rain", "Heavy rain"},
+	1198: {"Light freezing rain", "Light freezing rain"},
+	1201: {"Moderate or heavy freezing rain", "Moderate or heavy freezing rain"},
+	1204: {"Light sleet", "Light sleet"},
+	1207: {"Moderate or heavy sleet", "Moderate or heavy sleet"},
+	1210: {"Patchy light snow", "Patchy light snow"},
+	1213: {"Light snow", "Light snow"},
+	1216: {"Patchy moderate snow", "Patchy moderate snow"},
+	1219: {"Moderate snow", "Moderate snow"},
+	1222: {"Patchy heavy snow", "Patchy heavy snow"},
+	1225: {"Heavy snow", "Heavy snow"},
+	1237: {"Ice pellets", "Ice pellets"},
+	1240: {"Light rain shower", "Light rain shower"},
+	1243: {"Moderate or heavy rain shower", "Moderate or heavy rain shower"},
+	1246: {"Torrential rain shower", "Torrential rain shower"},
+	1249: {"Light sleet showers", "Light sleet showers"},
+	1252: {"Moderate or heavy sleet showers", "Moderate or heavy sleet showers"},
+	1255: {"Light snow showers", "Light snow showers"},
+	1258: {"Moderate or heavy snow showers", "Moderate or heavy snow showers"},
+	1261: {"Light showers of ice pellets", "Light showers of ice pellets"},
+	1264: {"Moderate or heavy showers of ice pellets", "Moderate or heavy showers of ice pellets"},
+	1273: {"Patchy light rain with thunder", "Patchy light rain with thunder"},
+	1276: {"Moderate or heavy rain with thunder", "Moderate or heavy rain with thunder"},
+	1279: {"Patchy light snow with thunder", "Patchy light snow with thunder"},
+	1282: {"Moderate or heavy snow with thunder", "Moderate or heavy snow with thunder"},
+}
+
+// NewCondition construye una Condition a partir de un código de WeatherAPI,
+// rellenando el texto estándar en inglés.
+func NewCondition(code int, isDay bool) Condition {
+	text := ""
+	if t, ok := conditionTexts[code]; ok {
+		text = t[0]
+		if !isDay {
+			text = t[1]
+		}
+	}
+	return Condition{Text: text, Code: code, IsDay: isDay}
+}
+
+// TranslateConditions pasa el texto de todas las condiciones de f por tr.
+// Lo usan los proveedores que solo dan el código, cuyo texto es el estándar
+// en inglés de NewCondition, para traducirlo al idioma pedido.
+func (f *Forecast) TranslateConditions(tr func(string) string) {
+	f.Current.Condition.Text = tr(f.Current.Condition.Text)
+	for i := range f.Days {
+		d := &f.Days[i]
+		d.Condition.Text = tr(d.Condition.Text)
+		for j := range d.Hours {
+			d.Hours[j].Condition.Text = tr(d.Hours[j].Condition.Text)
+		}
+	}
+}
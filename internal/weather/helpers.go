@@ -0,0 +1,46 @@
+package weather
+
+import "math"
+
+var compassPoints = []string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// CompassDir convierte grados (0 = norte) al punto cardinal de 16 rumbos
+func CompassDir(deg int) string {
+	d := ((deg % 360) + 360) % 360
+	idx := int(math.Round(float64(d)/22.5)) % 16
+	return compassPoints[idx]
+}
+
+// AggregateDay calcula los valores diarios a partir de las horas, para los
+// proveedores que no los devuelven ya resumidos. Los campos que no se
+// pueden derivar (astro, fecha...) quedan a cargo del llamador.
+func AggregateDay(hours []Hour) Day {
+	d := Day{Hours: hours}
+	if len(hours) == 0 {
+		return d
+	}
+	d.Date = hours[0].Time
+	d.MaxTempC = math.Inf(-1)
+	d.MinTempC = math.Inf(1)
+
+	var sumTemp float64
+	var sumHum int
+	for _, h := range hours {
+		d.MaxTempC = math.Max(d.MaxTempC, h.TempC)
+		d.MinTempC = math.Min(d.MinTempC, h.TempC)
+		d.MaxWindKph = math.Max(d.MaxWindKph, h.WindKph)
+		d.TotalPrecipMm += h.PrecipMm
+		d.ChanceOfRain = max(d.ChanceOfRain, int(math.Round(h.ChanceOfRain)))
+		d.ChanceOfSnow = max(d.ChanceOfSnow, int(math.Round(h.ChanceOfSnow)))
+		sumTemp += h.TempC
+		sumHum += h.Humidity
+	}
+	d.AvgTempC = sumTemp / float64(len(hours))
+	d.AvgHumidity = sumHum / len(hours)
+	// Condición representativa: la de mitad del periodo
+	d.Condition = hours[len(hours)/2].Condition
+	return d
+}
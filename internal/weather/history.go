@@ -0,0 +1,71 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HistoryProvider lo implementan los proveedores con datos observados.
+// History devuelve un Forecast con un único día (la fecha pedida).
+type HistoryProvider interface {
+	History(ctx context.Context, query string, date time.Time) (*Forecast, error)
+}
+
+// historyWorkers limita las peticiones simultáneas al pedir un rango
+const historyWorkers = 4
+
+// FetchHistory pide a p cada día entre from y to (ambos incluidos), en
+// paralelo y con timeout para cada petición (0 = sin límite propio), y los
+// une en un único Forecast ordenado por fecha.
+func FetchHistory(ctx context.Context, p Provider, query string, from, to time.Time, timeout time.Duration) (*Forecast, error) {
+	hp, ok := p.(HistoryProvider)
+	if !ok {
+		return nil, fmt.Errorf("%s: history: %w", p.Name(), ErrNotSupported)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid range: %s is after %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+
+	var dates []time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Al fallar un día se cancelan los demás, que devuelven context.Canceled;
+	// el error que cuenta es el primero, el que provocó la cancelación
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	results := make([]*Forecast, len(dates))
+	runLimited(ctx, len(dates), historyWorkers, timeout, func(dayCtx context.Context, i int) {
+		d := dates[i]
+		r, err := hp.History(dayCtx, query, d)
+		if err != nil {
+			once.Do(func() {
+				firstErr = fmt.Errorf("%s: %w", d.Format(time.DateOnly), err)
+				cancel() // no tiene sentido seguir si un día falla
+			})
+			return
+		}
+		results[i] = r
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	merged := &Forecast{}
+	for i, r := range results {
+		if i == 0 {
+			merged.Provider = r.Provider
+			merged.Location = r.Location
+		}
+		merged.Days = append(merged.Days, r.Days...)
+	}
+	return merged, nil
+}
@@ -0,0 +1,112 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// errQuota hace de error real de un proveedor, distinto de una cancelación
+var errQuota = errors.New("quota exceeded")
+
+// historyStub devuelve un día con la fecha pedida y lluvia = día del mes. El
+// día failOn falla con errQuota en el acto; los demás tardan delay.
+type historyStub struct {
+	failOn string
+	delay  time.Duration
+}
+
+func (h *historyStub) Name() string { return "stub" }
+
+func (h *historyStub) Forecast(ctx context.Context, req Request) (*Forecast, error) {
+	return nil, ErrNotSupported
+}
+
+func (h *historyStub) History(ctx context.Context, query string, date time.Time) (*Forecast, error) {
+	if date.Format(time.DateOnly) == h.failOn {
+		return nil, errQuota
+	}
+	select {
+	case <-time.After(h.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &Forecast{
+		Provider: "stub",
+		Location: Location{Name: query},
+		Days: []Day{{
+			Date:          date,
+			MaxTempC:      float64(20 + date.Day()),
+			MinTempC:      float64(10 - date.Day()),
+			TotalPrecipMm: float64(date.Day()),
+		}},
+	}, nil
+}
+
+func TestFetchHistory_MergesInOrder(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 6)
+
+	f, err := FetchHistory(context.Background(), &historyStub{}, "Vigo", from, to, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Days) != 7 || f.Location.Name != "Vigo" {
+		t.Fatalf("unexpected merge: %d days, location %q", len(f.Days), f.Location.Name)
+	}
+	for i, d := range f.Days {
+		if d.Date.Day() != i+1 {
+			t.Fatalf("days out of order: %v", d.Date)
+		}
+	}
+
+	s := Summarize(f.Days)
+	if s.TotalPrecipMm != 28 || s.RainyDays != 7 {
+		t.Errorf("unexpected totals: %+v", s)
+	}
+	if s.MaxTempC != 27 || s.MaxTempDate.Day() != 7 || s.MinTempC != 3 || s.WettestDate.Day() != 7 {
+		t.Errorf("unexpected extremes: %+v", s)
+	}
+}
+
+func TestFetchHistory_Errors(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// El fallo de un día tardío cancela los anteriores, pero el error que se
+	// devuelve es el suyo y no la cancelación de otro día
+	to := from.AddDate(0, 0, 2*historyWorkers)
+	stub := &historyStub{failOn: to.Format(time.DateOnly), delay: 20 * time.Millisecond}
+	_, err := FetchHistory(context.Background(), stub, "Vigo", from, to, time.Second)
+	if !errors.Is(err, errQuota) {
+		t.Fatalf("expected the failing day's error, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), to.Format(time.DateOnly)+": ") {
+		t.Errorf("error does not name the failing day: %v", err)
+	}
+	if _, err := FetchHistory(context.Background(), &historyStub{}, "Vigo", from, from.AddDate(0, 0, -1), time.Second); err == nil {
+		t.Fatal("expected error for reversed range")
+	}
+}
+
+// El plazo es de cada día: un rango largo tarda más que un plazo, pero ninguna
+// petición lo supera
+func TestFetchHistory_TimeoutPerDay(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 23)
+
+	stub := &historyStub{delay: 30 * time.Millisecond}
+	f, err := FetchHistory(context.Background(), stub, "Vigo", from, to, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("range longer than one timeout failed: %v", err)
+	}
+	if len(f.Days) != 24 {
+		t.Fatalf("expected 24 days, got %d", len(f.Days))
+	}
+
+	stub.delay = time.Second
+	if _, err := FetchHistory(context.Background(), stub, "Vigo", from, from, 10*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a per-day timeout, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package weather
+
+import (
+	"context"
+	"time"
+)
+
+// forecastWorkers limita las peticiones simultáneas al pedir varias
+// localizaciones
+const forecastWorkers = 4
+
+// Result es la previsión de una de las peticiones de FetchMany, o el error
+// con el que falló
+type Result struct {
+	Request  Request
+	Forecast *Forecast
+	Err      error
+}
+
+// FetchMany pide a p la previsión de cada petición en paralelo, con a lo
+// sumo forecastWorkers a la vez y timeout para cada una (0 = sin límite
+// propio), y devuelve los resultados en el orden de reqs. Que una
+// localización falle no cancela las demás.
+func FetchMany(ctx context.Context, p Provider, reqs []Request, timeout time.Duration) []Result {
+	results := make([]Result, len(reqs))
+	runLimited(ctx, len(reqs), forecastWorkers, timeout, func(ctx context.Context, i int) {
+		f, err := p.Forecast(ctx, reqs[i])
+		results[i] = Result{Request: reqs[i], Forecast: f, Err: err}
+	})
+	return results
+}
@@ -0,0 +1,79 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// manyStub tarda delay en cada consulta, falla con "Nowhere" y cuenta cuántas
+// peticiones llegan a estar en curso a la vez
+type manyStub struct {
+	delay        time.Duration
+	active, peak atomic.Int32
+}
+
+func (m *manyStub) Name() string { return "stub" }
+
+func (m *manyStub) Forecast(ctx context.Context, req Request) (*Forecast, error) {
+	n := m.active.Add(1)
+	defer m.active.Add(-1)
+	for {
+		peak := m.peak.Load()
+		if n <= peak || m.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	select {
+	case <-time.After(m.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if req.Query == "Nowhere" {
+		return nil, ErrLocationNotFound
+	}
+	return &Forecast{Location: Location{Name: req.Query}}, nil
+}
+
+func TestFetchMany_OrderAndBound(t *testing.T) {
+	queries := []string{"Vigo", "Madrid", "Nowhere", "Oslo", "Lisboa", "Porto", "Lugo", "Ourense"}
+	reqs := make([]Request, len(queries))
+	for i, q := range queries {
+		reqs[i] = Request{Query: q, Days: 1}
+	}
+
+	stub := &manyStub{delay: 10 * time.Millisecond}
+	results := FetchMany(context.Background(), stub, reqs, time.Second)
+	if len(results) != len(queries) {
+		t.Fatalf("got %d results, want %d", len(results), len(queries))
+	}
+	for i, r := range results {
+		if r.Request.Query != queries[i] {
+			t.Fatalf("result %d is for %q, want %q", i, r.Request.Query, queries[i])
+		}
+		if r.Request.Query == "Nowhere" {
+			if !errors.Is(r.Err, ErrLocationNotFound) {
+				t.Errorf("Nowhere: got error %v", r.Err)
+			}
+			continue
+		}
+		if r.Err != nil || r.Forecast.Location.Name != queries[i] {
+			t.Errorf("%s: got %+v, %v", queries[i], r.Forecast, r.Err)
+		}
+	}
+	if peak := stub.peak.Load(); peak > forecastWorkers || peak < 2 {
+		t.Errorf("peak concurrency %d, want between 2 and %d", peak, forecastWorkers)
+	}
+}
+
+func TestFetchMany_Timeout(t *testing.T) {
+	reqs := []Request{{Query: "Vigo"}, {Query: "Madrid"}}
+	results := FetchMany(context.Background(), &manyStub{delay: time.Second}, reqs, 10*time.Millisecond)
+	for _, r := range results {
+		if !errors.Is(r.Err, context.DeadlineExceeded) {
+			t.Errorf("%s: got %v, want deadline exceeded", r.Request.Query, r.Err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package weather define el modelo de datos neutral respecto al proveedor
+// y la interfaz Provider que implementa cada backend (WeatherAPI,
+// Open-Meteo, MET Norway...). El resto del programa (render, comandos)
+// trabaja solo con estos tipos.
+//
+// Todas las magnitudes se guardan en unidades métricas: °C, km/h, hPa, mm,
+// cm y km.
+package weather
+
+import "time"
+
+// Forecast es la previsión completa de una localización
+type Forecast struct {
+	Provider string   `json:"provider"`
+	Location Location `json:"location"`
+	Current  Current  `json:"current"`
+	Days     []Day    `json:"days"`
+	Alerts   []Alert  `json:"alerts,omitempty"`
+}
+
+type Location struct {
+	Name     string  `json:"name"`
+	Region   string  `json:"region,omitempty"`
+	Country  string  `json:"country,omitempty"`
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	TimeZone string  `json:"tz_id,omitempty"`
+}
+
+// Condition describe el estado del cielo. Code sigue la numeración de
+// condiciones de WeatherAPI (1000 despejado, 1003 parcialmente nuboso...);
+// los demás proveedores traducen sus códigos a ella.
+type Condition struct {
+	Text  string `json:"text"`
+	Code  int    `json:"code"`
+	IsDay bool   `json:"is_day"`
+}
+
+type Current struct {
+	Time       time.Time `json:"time"`
+	TempC      float64   `json:"temp_c"`
+	FeelsLikeC float64   `json:"feelslike_c"`
+	Condition  Condition `json:"condition"`
+	WindKph    float64   `json:"wind_kph"`
+	WindDegree int       `json:"wind_degree"`
+	WindDir    string    `json:"wind_dir,omitempty"`
+	GustKph    float64   `json:"gust_kph"`
+	Humidity   int       `json:"humidity"`
+	PressureMb float64   `json:"pressure_mb"`
+	PrecipMm   float64   `json:"precip_mm"`
+	Cloud      int       `json:"cloud"`
+	UV         float64   `json:"uv"`
+	VisKm      float64   `json:"vis_km"`
+
+	AirQuality *AirQuality `json:"air_quality,omitempty"`
+}
+
+// AirQuality son las concentraciones de contaminantes en μg/m³ y los
+// índices US-EPA (1 bueno .. 6 peligroso) y UK DEFRA (1 .. 10).
+type AirQuality struct {
+	CO           float64 `json:"co"`
+	NO2          float64 `json:"no2"`
+	O3           float64 `json:"o3"`
+	SO2          float64 `json:"so2"`
+	PM25         float64 `json:"pm2_5"`
+	PM10         float64 `json:"pm10"`
+	USEPAIndex   int     `json:"us_epa_index"`
+	GBDefraIndex int     `json:"gb_defra_index"`
+}
+
+// Alert es un aviso meteorológico oficial para la zona
+type Alert struct {
+	Headline    string    `json:"headline"`
+	Event       string    `json:"event"`
+	Severity    string    `json:"severity"` // Extreme, Severe, Moderate, Minor, Unknown
+	Urgency     string    `json:"urgency,omitempty"`
+	Areas       string    `json:"areas,omitempty"`
+	Effective   time.Time `json:"effective"`
+	Expires     time.Time `json:"expires"`
+	Description string    `json:"description,omitempty"`
+	Instruction string    `json:"instruction,omitempty"`
+}
+
+type Day struct {
+	Date          time.Time `json:"date"`
+	MaxTempC      float64   `json:"maxtemp_c"`
+	MinTempC      float64   `json:"mintemp_c"`
+	AvgTempC      float64   `json:"avgtemp_c"`
+	MaxWindKph    float64   `json:"maxwind_kph"`
+	TotalPrecipMm float64   `json:"totalprecip_mm"`
+	TotalSnowCm   float64   `json:"totalsnow_cm"`
+	AvgHumidity   int       `json:"avghumidity"`
+	ChanceOfRain  int       `json:"chance_of_rain"`
+	ChanceOfSnow  int       `json:"chance_of_snow"`
+	UV            float64   `json:"uv"`
+	Condition     Condition `json:"condition"`
+	Astro         Astro     `json:"astro"`
+	Hours         []Hour    `json:"hours"`
+
+	AirQuality *AirQuality `json:"air_quality,omitempty"`
+}
+
+// Astro guarda las horas de orto y ocaso tal y como se muestran
+// (formato "03:04 PM" en hora local de la localización).
+type Astro struct {
+	Sunrise string `json:"sunrise,omitempty"`
+	Sunset  string `json:"sunset,omitempty"`
+}
+
+type Hour struct {
+	Time         time.Time `json:"time"`
+	TempC        float64   `json:"temp_c"`
+	FeelsLikeC   float64   `json:"feelslike_c"`
+	Condition    Condition `json:"condition"`
+	WindKph      float64   `json:"wind_kph"`
+	WindDegree   int       `json:"wind_degree"`
+	WindDir      string    `json:"wind_dir,omitempty"`
+	Humidity     int       `json:"humidity"`
+	PrecipMm     float64   `json:"precip_mm"`
+	ChanceOfRain float64   `json:"chance_of_rain"`
+	ChanceOfSnow float64   `json:"chance_of_snow"`
+}
@@ -0,0 +1,32 @@
+package weather
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// runLimited llama a task para cada índice de 0 a n-1 en paralelo, con a lo
+// sumo workers a la vez, y espera a que acaben todas. Cada tarea recibe un
+// ctx con su propio timeout (0 = sin límite propio), que corre desde que la
+// tarea empieza y no mientras espera turno.
+func runLimited(ctx context.Context, n, workers int, timeout time.Duration, task func(ctx context.Context, i int)) {
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			taskCtx, cancel := ctx, context.CancelFunc(func() {})
+			if timeout > 0 {
+				taskCtx, cancel = context.WithTimeout(ctx, timeout)
+			}
+			defer cancel()
+			task(taskCtx, i)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,91 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Request describe qué previsión se pide a un proveedor
+type Request struct {
+	Query  string // nombre de ciudad o "lat,lon"
+	Days   int
+	AQI    bool // incluir calidad del aire si el proveedor la ofrece
+	Alerts bool // incluir avisos oficiales si el proveedor los ofrece
+}
+
+// Provider es un backend capaz de devolver una previsión en el modelo neutral
+type Provider interface {
+	Name() string
+	Forecast(ctx context.Context, req Request) (*Forecast, error)
+}
+
+// CurrentProvider lo implementan los proveedores con un endpoint específico
+// para las condiciones actuales. El Forecast devuelto puede no traer días.
+// De req solo se usan Query y AQI.
+type CurrentProvider interface {
+	Current(ctx context.Context, req Request) (*Forecast, error)
+}
+
+// FetchCurrent obtiene las condiciones actuales de p, usando su endpoint
+// específico si lo tiene o una previsión de un día en caso contrario.
+func FetchCurrent(ctx context.Context, p Provider, req Request) (*Forecast, error) {
+	if cp, ok := p.(CurrentProvider); ok {
+		return cp.Current(ctx, req)
+	}
+	req.Days = 1
+	return p.Forecast(ctx, req)
+}
+
+// Searcher lo implementan los proveedores que permiten buscar localizaciones
+type Searcher interface {
+	Search(ctx context.Context, text string) ([]Location, error)
+}
+
+// Search busca localizaciones con p, o devuelve ErrNotSupported si el
+// proveedor no sabe hacerlo.
+func Search(ctx context.Context, p Provider, text string) ([]Location, error) {
+	if s, ok := p.(Searcher); ok {
+		return s.Search(ctx, text)
+	}
+	return nil, fmt.Errorf("%s: search: %w", p.Name(), ErrNotSupported)
+}
+
+// Geocoder resuelve una consulta de texto a una localización con coordenadas.
+// Lo usan los proveedores que solo aceptan lat/lon.
+type Geocoder interface {
+	Geocode(ctx context.Context, query string) (Location, error)
+}
+
+// ErrLocationNotFound se devuelve (o se puede comparar con errors.Is) cuando
+// la consulta no corresponde a ninguna localización conocida.
+var ErrLocationNotFound = errors.New("location not found")
+
+// ErrNotSupported indica que el proveedor no ofrece la operación pedida
+var ErrNotSupported = errors.New("operation not supported by provider")
+
+// HTTPError es el error genérico de los proveedores ante respuestas no-2xx
+type HTTPError struct {
+	Provider string
+	Status   int
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%s: http %d", e.Provider, e.Status)
+}
+
+// ParseCoords interpreta consultas del tipo "42.23,-8.72".
+func ParseCoords(q string) (lat, lon float64, ok bool) {
+	a, b, found := strings.Cut(q, ",")
+	if !found {
+		return 0, 0, false
+	}
+	lat, err1 := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	lon, err2 := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if err1 != nil || err2 != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
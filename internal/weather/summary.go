@@ -0,0 +1,56 @@
+package weather
+
+import "time"
+
+// Summary resume un conjunto de días: totales y extremos con su fecha
+type Summary struct {
+	Days          int       `json:"days"`
+	RainyDays     int       `json:"rainy_days"` // días con al menos 0.1 mm
+	TotalPrecipMm float64   `json:"totalprecip_mm"`
+	TotalSnowCm   float64   `json:"totalsnow_cm"`
+	AvgTempC      float64   `json:"avgtemp_c"`
+	MaxTempC      float64   `json:"maxtemp_c"`
+	MaxTempDate   time.Time `json:"maxtemp_date"`
+	MinTempC      float64   `json:"mintemp_c"`
+	MinTempDate   time.Time `json:"mintemp_date"`
+	MaxWindKph    float64   `json:"maxwind_kph"`
+	MaxWindDate   time.Time `json:"maxwind_date"`
+	WettestMm     float64   `json:"wettest_mm"`
+	WettestDate   time.Time `json:"wettest_date"`
+}
+
+// Summarize calcula el resumen de days. Con una lista vacía devuelve el
+// valor cero.
+func Summarize(days []Day) Summary {
+	var s Summary
+	if len(days) == 0 {
+		return s
+	}
+	s.Days = len(days)
+	s.MaxTempC, s.MaxTempDate = days[0].MaxTempC, days[0].Date
+	s.MinTempC, s.MinTempDate = days[0].MinTempC, days[0].Date
+
+	var sumAvg float64
+	for _, d := range days {
+		s.TotalPrecipMm += d.TotalPrecipMm
+		s.TotalSnowCm += d.TotalSnowCm
+		sumAvg += d.AvgTempC
+		if d.TotalPrecipMm >= 0.1 {
+			s.RainyDays++
+		}
+		if d.MaxTempC > s.MaxTempC {
+			s.MaxTempC, s.MaxTempDate = d.MaxTempC, d.Date
+		}
+		if d.MinTempC < s.MinTempC {
+			s.MinTempC, s.MinTempDate = d.MinTempC, d.Date
+		}
+		if d.MaxWindKph > s.MaxWindKph {
+			s.MaxWindKph, s.MaxWindDate = d.MaxWindKph, d.Date
+		}
+		if d.TotalPrecipMm > s.WettestMm {
+			s.WettestMm, s.WettestDate = d.TotalPrecipMm, d.Date
+		}
+	}
+	s.AvgTempC = sumAvg / float64(len(days))
+	return s
+}